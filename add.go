@@ -2,11 +2,20 @@ package rangeset
 
 // add.go implements methods to add to a rangeset
 
+import (
+	"slices"
+)
+
 // Add inserts a single element into the set
 // It returns true if added or false if it already existed in the set
 // It has time complexity O(log r) where r is the number of ranges or O(log n) worst case.
 func (s *Set[T]) Add(e T) bool {
-	idx := s.bsearch(e)
+	return s.addAt(s.bsearch(e), e)
+}
+
+// addAt inserts a single element into the set given the index of the range above it
+// (as returned by bsearch).  It returns true if added or false if it was already present.
+func (s *Set[T]) addAt(idx int, e T) bool {
 	//assert(idx >= 0 && idx <= len(*s))
 	var endMark = minInt[T]() // in a range it flags: bottom/top of all valid elements
 	if idx == 0 || (e > (*s)[idx-1].Top && (*s)[idx-1].Top != endMark) {
@@ -94,3 +103,13 @@ func (s *Set[T]) AddRange(b, t T) {
 		(*s)[bIdx-1].Top = t
 	}
 }
+
+// AddSorted inserts the elements of a slice into the set.  If the elements are in ascending order
+// (duplicates are allowed) it has time complexity O(n+r), otherwise they are sorted first.
+func (s *Set[T]) AddSorted(elems []T) {
+	if !slices.IsSorted(elems) {
+		*s = unionOf(*s, FromSlice(elems))
+		return
+	}
+	*s = unionOf(*s, fromSorted(elems))
+}
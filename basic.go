@@ -1,6 +1,7 @@
 package rangeset
 
 import (
+	"math/big"
 	"slices"
 )
 
@@ -19,13 +20,9 @@ type (
 )
 
 // Make creates a new set optionally taking initial element(s)
-// TODO: check if sorting elems (in copy of it) could improve perf. for large len(elems)
+// The elements are sorted (see FromSlice) so it has time complexity O(n log n).
 func Make[T Element](elems ...T) Set[T] {
-	s := make(Set[T], 0, len(elems))
-	for _, v := range elems {
-		_ = s.Add(v)
-	}
-	return s
+	return FromSlice(elems)
 }
 
 // NewFromRange creates a new set by specifying an initial range of elements
@@ -49,19 +46,42 @@ func Universal[T Element]() Set[T] {
 	return Set[T]{{endMark, endMark}}
 }
 
-// Length returns the number of elements and number of ranges in the set.
+// Length returns the number of elements and number of ranges in the set.  Every element is
+// counted, even in a range with more elements than the largest value of the element type (eg
+// {-100:99} of int8 has 200 elements, and a universal set of uint16 has 65536 elements).
 // Note if the element type is 64-bit the size of a universal set is too large to be represented
 // as uint64 - in this case 0 is returned for the number of elements and 1 for the number of spans.
+// (Use LengthBig or Length128 to get the exact number of elements.)
 // It has time complexity of O(r) where r is the number of ranges, and O(n) in the worst case.
 func (s Set[T]) Length() (length uint64, spans int) {
 	spans = len(s)
 	for _, r := range s {
-		// assert(r.t > r.b)
-		length += uint64(r.Top - r.Bot)
+		length += rangeLen(r.Bot, r.Top-1)
 	}
 	return
 }
 
+// LengthBig returns the exact number of elements in the set.  Unlike Length, the result is
+// correct for a universal set of a 64-bit type (2^64).
+func (s Set[T]) LengthBig() *big.Int {
+	return s.total().bigInt()
+}
+
+// Length128 returns the exact number of elements in the set as a 128-bit unsigned integer
+// (hi*2^64 + lo) - ie hi is only non-zero (one) for the universal set of a 64-bit type.
+func (s Set[T]) Length128() (hi, lo uint64) {
+	c := s.total()
+	return c.hi, c.lo
+}
+
+// rangeLen returns the number of elements in the range [b, last] (ie inclusive bounds)
+// Note that for a 64-bit element type the range of all elements returns 0 (overflow).
+func rangeLen[T Element](b, last T) uint64 {
+	// Converting to uint64 sign-extends signed values but the difference is still correct, even
+	// when it is too big for T (eg a range of 200 int8 elements)
+	return uint64(last) - uint64(b) + 1
+}
+
 // Len returns the number of elements, or -1 if it's more than the largest int.
 // It has time complexity of O(r) where r is the number of ranges, and O(n) in the worst case.
 // Note: As sets are stored using ranges it is easy to have huge sets, where the number of
@@ -84,17 +104,94 @@ func (s Set[T]) Contains(e T) bool {
 	return idx > 0 && (e < s[idx-1].Top || s[idx-1].Top == endMark)
 }
 
+// ContainsRange tests whether a set contains every element in the range [b, t) (asymmetric bounds).
+// An empty range (t <= b) is always contained.  It has time complexity O(log r).
+func (s Set[T]) ContainsRange(b, t T) bool {
+	var endMark = minInt[T]() // in a range it flags: bottom/top of all valid elements
+	if t <= b && t != endMark {
+		return true // empty range
+	}
+	// Since ranges are never adjacent all the elements must be in the same range
+	idx := s.bsearch(b)
+	return idx > 0 && b <= s[idx-1].Top-1 && t-1 <= s[idx-1].Top-1
+}
+
+// ContainsAny tests whether a set contains at least one element in the range [b, t).
+// It has time complexity O(log r) where r is the number of ranges.
+func (s Set[T]) ContainsAny(b, t T) bool {
+	var endMark = minInt[T]() // in a range it flags: bottom/top of all valid elements
+	if t <= b && t != endMark {
+		return false // empty range
+	}
+	idx := s.bsearch(b)
+	return idx > 0 && b <= s[idx-1].Top-1 || idx < len(s) && s[idx].Bot <= t-1
+}
+
+// ContainsAll tests whether a set contains all the elements passed to it.
+// It has time complexity O(k log r) where k is the number of elements.
+func (s Set[T]) ContainsAll(elems ...T) bool {
+	for _, e := range elems {
+		if !s.Contains(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// Coverage returns the number of elements of the range [b, t) that are in the set and the number
+// of ranges of the set that overlap it.  Like Length(), if the result is all the elements of
+// a 64-bit type, 0 is returned.  It has time complexity O(log r + k) where k is the number
+// of ranges of the set that overlap [b, t), since the lengths of those ranges must be added.
+// For O(log r) use an Indexed set (see Indexed.Coverage) which keeps a running count.
+func (s Set[T]) Coverage(b, t T) (length uint64, spans int) {
+	c, spans := s.coverage(b, t)
+	return c.lo, spans
+}
+
+// CoverageBig is the same as Coverage but returns the exact number of elements
+func (s Set[T]) CoverageBig(b, t T) (*big.Int, int) {
+	c, spans := s.coverage(b, t)
+	return c.bigInt(), spans
+}
+
+// coverage returns the exact number of elements of [b, t) in the set and the number of ranges
+func (s Set[T]) coverage(b, t T) (length count, spans int) {
+	var endMark = minInt[T]() // in a range it flags: bottom/top of all valid elements
+	if t <= b && t != endMark {
+		return
+	}
+	last := t - 1
+	for idx := max(s.bsearch(b)-1, 0); idx < len(s) && s[idx].Bot <= last; idx++ {
+		if lo, hi := max(s[idx].Bot, b), min(s[idx].Top-1, last); lo <= hi {
+			length.addLen(rangeLen(lo, hi))
+			spans++
+		}
+	}
+	return
+}
+
+// SpanOf returns the range of the set that contains e and its index (in the slice of ranges).
+// If e is not in the set it returns an index of -1.  It has time complexity O(log r).
+func (s Set[T]) SpanOf(e T) (Span[T], int) {
+	idx := s.bsearch(e)
+	if idx == 0 || e > s[idx-1].Top-1 {
+		return Span[T]{}, -1
+	}
+	return s[idx-1], idx - 1
+}
+
 // Values returns all the values in the set as a slice (in numeric order).
 // WARNING: if your range set contains large ranges this may take a
 // long time and return a slice with a large number of elements.
 //
 // Deprecated: from Go 12.3 use the Seq() method to obtain an iterator
 func (s Set[T]) Values() []T {
-	retval := make([]T, 0, s.Len())
+	retval := make([]T, 0, max(s.Len(), 0))
 	for _, v := range s {
-		for e := v.Bot; e < v.Top; e++ {
+		v.walk(func(e T) bool {
 			retval = append(retval, e)
-		}
+			return true
+		})
 	}
 	return retval
 }
@@ -118,30 +215,36 @@ func (s Set[T]) Copy() Set[T] {
 }
 
 // AddSet finds the union of s with s2 (ie, adds all the elements of s2 to s)
+// It merges the ranges of both sets in a single pass, so has time complexity O(r1+r2).
 func (s *Set[T]) AddSet(s2 Set[T]) {
-	for _, v := range s2 {
-		s.AddRange(v.Bot, v.Top)
-	}
+	*s = unionOf(*s, s2)
 }
 
 // SubSet removes all elements of s2 from s
+// It merges the ranges of both sets in a single pass, so has time complexity O(r1+r2).
 func (s *Set[T]) SubSet(s2 Set[T]) {
-	for _, v := range s2 {
-		s.DeleteRange(v.Bot, v.Top)
-	}
+	*s = differenceOf(*s, s2)
 }
 
 // Intersect finds the intersection of s with s2 (ie, deletes from s any elts not in s2)
+// It merges the ranges of both sets in a single pass, so has time complexity O(r1+r2).
 func (s *Set[T]) Intersect(s2 Set[T]) {
-	endMark := minInt[T]()
-	bDel := endMark
-	for _, v := range s2 {
-		if bDel != endMark || v.Bot != endMark {
-			s.DeleteRange(bDel, v.Bot)
-		}
-		bDel = v.Top
-	}
-	if len(s2) == 0 || bDel != endMark {
-		s.DeleteRange(bDel, endMark)
+	*s = intersectionOf(*s, s2)
+}
+
+// SymmetricDifference finds the elements that are in s or s2 but not both (ie, adds the elements
+// of s2 not in s and deletes those that are).  It has time complexity O(r1+r2).
+func (s *Set[T]) SymmetricDifference(s2 Set[T]) {
+	*s = symmetricDifferenceOf(*s, s2)
+}
+
+// ToggleRange inverts the membership of a range of elements - elements in the range that are in
+// the set are deleted and those that are not are added.  Like AddRange() it uses asymmetric bounds.
+// It has time complexity O(r) where r is the number of ranges.
+func (s *Set[T]) ToggleRange(b, t T) {
+	var endMark = minInt[T]() // indicates top/bottom of range of valid elements
+	if t <= b && t != endMark {
+		return // nothing to toggle
 	}
+	*s = symmetricDifferenceOf(*s, Set[T]{{b, t}})
 }
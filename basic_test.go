@@ -2,6 +2,7 @@ package rangeset_test
 
 import (
 	"github.com/andrewwphillips/rangeset"
+	"math/rand/v2"
 	"testing"
 )
 
@@ -92,3 +93,118 @@ func TestEmptyAndUniversalComplement(t *testing.T) {
 	Assertf(t, rangeset.Equal(empty, complement), "%24s: expected complement of U to be %v got %v\n",
 		"TestEmptyUniversal", empty, complement)
 }
+
+// TestLengthWide checks Length() and Len() of ranges with more elements than the largest value
+// of the element type, including universal sets (where only 64-bit types can't be counted)
+func TestLengthWide(t *testing.T) {
+	for name, data := range map[string]struct {
+		length   func() (uint64, int)
+		expected uint64
+	}{
+		"Int8Range":   {rangeset.NewFromRange[int8](-100, 100).Length, 200},
+		"Int8":        {rangeset.Universal[int8]().Length, 1 << 8},
+		"Uint8":       {rangeset.Universal[uint8]().Length, 1 << 8},
+		"Int16Range":  {rangeset.NewFromRange[int16](-30000, 30000).Length, 60000},
+		"Uint16":      {rangeset.Universal[uint16]().Length, 1 << 16},
+		"Int32":       {rangeset.Universal[int32]().Length, 1 << 32},
+		"Uint32Range": {rangeset.NewFromRange[uint32](1, 1<<32-1).Length, 1<<32 - 2},
+		"Int64":       {rangeset.Universal[int64]().Length, 0}, // 2^64 is too big
+	} {
+		length, spans := data.length()
+		Assertf(t, length == data.expected && spans == 1, "LengthWide: %12s: expected %d,1 got %d,%d", name, data.expected, length, spans)
+	}
+	Assertf(t, rangeset.Universal[int8]().Len() == 256, "LengthWide: expected Len of universal int8 set 256 got %d", rangeset.Universal[int8]().Len())
+	Assertf(t, rangeset.Universal[int64]().Len() == -1, "LengthWide: expected Len of universal int64 set -1 got %d", rangeset.Universal[int64]().Len())
+}
+
+// containsData provides table data for testing ContainsRange, ContainsAny and Coverage using the set {1:5,10:20}
+var containsData = map[string]struct {
+	b, t     elementType
+	all, any bool
+	length   uint64
+	spans    int
+}{
+	"Empty":    {3, 3, true, false, 0, 0},
+	"Reversed": {4, 2, true, false, 0, 0},
+	"One":      {3, 4, true, true, 1, 1},
+	"Whole":    {1, 6, true, true, 5, 1},
+	"Inside":   {11, 19, true, true, 8, 1},
+	"Before":   {-5, 1, false, false, 0, 0},
+	"Gap":      {6, 10, false, false, 0, 0},
+	"Bottom":   {0, 3, false, true, 2, 1},
+	"Top":      {15, 25, false, true, 6, 1},
+	"IntoGap":  {4, 8, false, true, 2, 1},
+	"Across":   {3, 12, false, true, 5, 2},
+	"All":      {0, 100, false, true, 16, 2},
+	"After":    {21, 100, false, false, 0, 0},
+}
+
+// TestContainsTable tests the ContainsRange, ContainsAny and Coverage methods using the containsData table
+func TestContainsTable(t *testing.T) {
+	s, _ := rangeset.NewFromString[elementType]("{1:5,10:20}")
+	for name, data := range containsData {
+		got := s.ContainsRange(data.b, data.t)
+		Assertf(t, got == data.all, "ContainsRange: %12s: [%d,%d) expected %t got %t", name, data.b, data.t, data.all, got)
+		got = s.ContainsAny(data.b, data.t)
+		Assertf(t, got == data.any, "ContainsAny: %12s: [%d,%d) expected %t got %t", name, data.b, data.t, data.any, got)
+		length, spans := s.Coverage(data.b, data.t)
+		Assertf(t, length == data.length && spans == data.spans, "Coverage: %12s: [%d,%d) expected %d,%d got %d,%d",
+			name, data.b, data.t, data.length, data.spans, length, spans)
+	}
+	Assertf(t, s.ContainsAll(), "ContainsAll: expected true for no elements")
+	Assertf(t, s.ContainsAll(1, 5, 20), "ContainsAll: expected true for 1, 5, 20")
+	Assertf(t, !s.ContainsAll(1, 6, 20), "ContainsAll: expected false for 1, 6, 20")
+
+	span, idx := s.SpanOf(12)
+	Assertf(t, idx == 1 && span == rangeset.Span[elementType]{10, 21}, "SpanOf: expected {10,21} at 1 got %v at %d", span, idx)
+	span, idx = s.SpanOf(7)
+	Assertf(t, idx == -1, "SpanOf: expected -1 for element not in set got %v at %d", span, idx)
+}
+
+// testContainsBruteForce checks the range containment methods against brute force calculations
+func testContainsBruteForce[T int8 | uint8](t *testing.T, name string) {
+	endMark := rangeset.Universal[T]()[0].Top
+	r := rand.New(rand.NewPCG(19, 20))
+	for range 500 {
+		s := randomSet[T](r)
+		b, top := T(r.IntN(256)), T(r.IntN(256))
+		if r.IntN(4) == 0 {
+			top = endMark
+		}
+		all, any, count := true, false, 0
+		for i := 0; i < 256; i++ {
+			if e := T(i); e >= b && (e < top || top == endMark) && (top > b || top == endMark) {
+				all = all && s.Contains(e)
+				any = any || s.Contains(e)
+				if s.Contains(e) {
+					count++
+				}
+			}
+		}
+		got := s.ContainsRange(b, top)
+		Assertf(t, got == all, "%s ContainsRange: %v [%d,%d) expected %t got %t", name, s, b, top, all, got)
+		got = s.ContainsAny(b, top)
+		Assertf(t, got == any, "%s ContainsAny: %v [%d,%d) expected %t got %t", name, s, b, top, any, got)
+		length, spans := s.Coverage(b, top)
+		expLength, expSpans := rangeset.IntersectionLength(s, rangeset.Set[T]{{b, top}})
+		if top <= b && top != endMark {
+			expLength, expSpans = 0, 0
+		}
+		Assertf(t, int(length) == count && length == expLength && spans == expSpans, "%s Coverage: %v [%d,%d) expected %d,%d got %d,%d",
+			name, s, b, top, count, expSpans, length, spans)
+
+		span, idx := s.SpanOf(b)
+		if s.Contains(b) {
+			Assertf(t, idx >= 0 && s[idx] == span && span.Bot <= b && b <= span.Top-1, "%s SpanOf: %v (%d) got %v at %d",
+				name, s, b, span, idx)
+		} else {
+			Assertf(t, idx == -1, "%s SpanOf: %v (%d) expected -1 got %d", name, s, b, idx)
+		}
+	}
+}
+
+// TestContainsBruteForce tests the range containment methods using 8-bit elements
+func TestContainsBruteForce(t *testing.T) {
+	testContainsBruteForce[int8](t, "int8")
+	testContainsBruteForce[uint8](t, "uint8")
+}
@@ -0,0 +1,64 @@
+package rangeset
+
+// builder.go implements the Builder type which is used to efficiently create a set by
+// adding elements that are (mostly) in ascending order.
+
+// Builder creates a set by adding elements one at a time.  Adding an element above all those
+// already added takes constant (amortized) time.  Otherwise, the index of the range where the
+// previous element was added is used as a hint, so elements close to the previous one are
+// added without a binary search.  The zero value is an empty Builder ready to use.
+type Builder[T Element] struct {
+	set  Set[T]
+	hint int // index of the range that contains the last element added
+}
+
+// Add inserts an element into the set being built
+func (bd *Builder[T]) Add(e T) {
+	idx := bd.search(e)
+	bd.set.addAt(idx, e)
+	// Remember the range that now contains e
+	if idx < len(bd.set) && bd.set[idx].Bot <= e {
+		bd.hint = idx
+	} else {
+		bd.hint = idx - 1
+	}
+}
+
+// AddRange inserts the range of elements [b, t) into the set being built
+func (bd *Builder[T]) AddRange(b, t T) {
+	var endMark = minInt[T]() // indicates top/bottom of range of valid elements
+	if t <= b && t != endMark {
+		return // nothing needs to be added
+	}
+	if n := len(bd.set); n == 0 || bd.set[n-1].Top != endMark && b > bd.set[n-1].Top {
+		// Fast path - the range is after (and not adjacent to) the last range
+		bd.set = append(bd.set, Span[T]{b, t})
+		bd.hint = n
+		return
+	}
+	idx := bd.search(b)
+	if idx > 0 && (bd.set[idx-1].Top == endMark || b <= bd.set[idx-1].Top) {
+		idx-- // the range is joined onto the range below b
+	}
+	bd.set.AddRange(b, t)
+	bd.hint = idx // the range that now contains b
+}
+
+// search returns the index of the range above e (like bsearch) but first checks if e is in or
+// just after the range given by the hint
+func (bd *Builder[T]) search(e T) int {
+	if h := bd.hint; h >= 0 && h < len(bd.set) && bd.set[h].Bot <= e && (h+1 == len(bd.set) || e < bd.set[h+1].Bot) {
+		return h + 1
+	}
+	return bd.set.bsearch(e)
+}
+
+// Build returns the set that has been built and resets the Builder (to an empty set)
+func (bd *Builder[T]) Build() Set[T] {
+	retval := bd.set
+	if retval == nil {
+		retval = Set[T]{}
+	}
+	bd.set, bd.hint = nil, 0
+	return retval
+}
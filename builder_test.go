@@ -0,0 +1,131 @@
+package rangeset_test
+
+import (
+	"github.com/andrewwphillips/rangeset"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+type BuilderElementType int
+
+// builderData is for table-driven tests of the Builder type
+var builderData = map[string]struct {
+	elts     []BuilderElementType
+	expected string
+}{
+	"Empty":      {nil, "{}"},
+	"One":        {[]BuilderElementType{42}, "{42}"},
+	"Ascending":  {[]BuilderElementType{1, 2, 3, 5, 6, 9}, "{1:3,5:6,9}"},
+	"Descending": {[]BuilderElementType{9, 6, 5, 3, 2, 1}, "{1:3,5:6,9}"},
+	"Dupes":      {[]BuilderElementType{1, 1, 2, 2, 2, 5, 5}, "{1:2,5}"},
+	"Joins":      {[]BuilderElementType{1, 3, 5, 2, 4}, "{1:5}"},
+	"Mixed":      {[]BuilderElementType{10, 11, 1, 12, 2, 20, 13}, "{1:2,10:13,20}"},
+	"Extremes":   {[]BuilderElementType{1<<63 - 1, -1 << 63, 0}, "{-9223372036854775808,0,9223372036854775807}"},
+}
+
+// TestBuilderTable tests the Builder type and AddSorted using the builderData table
+func TestBuilderTable(t *testing.T) {
+	for name, data := range builderData {
+		expected, _ := rangeset.NewFromString[BuilderElementType](data.expected)
+		var bd rangeset.Builder[BuilderElementType]
+		for _, e := range data.elts {
+			bd.Add(e)
+		}
+		got := bd.Build()
+		Assertf(t, got != nil && rangeset.Equal(got, expected), "Builder: %12s: expected %v got %v", name, expected, got)
+		got = bd.Build()
+		Assertf(t, got != nil && len(got) == 0, "Builder: %12s: expected empty set after Build got %v", name, got)
+
+		got = rangeset.Make[BuilderElementType](4, 7)
+		got.AddSorted(data.elts)
+		expected.AddSet(rangeset.Make[BuilderElementType](4, 7))
+		Assertf(t, rangeset.Equal(got, expected), "AddSorted: %12s: expected %v got %v", name, expected, got)
+	}
+}
+
+// testBuilderBruteForce checks the Builder and AddSorted against adding elements to a set
+func testBuilderBruteForce[T int8 | uint8](t *testing.T, name string) {
+	endMark := rangeset.Universal[T]()[0].Top
+	r := rand.New(rand.NewPCG(37, 38))
+	for range 200 {
+		var bd rangeset.Builder[T]
+		var expected rangeset.Set[T]
+		var elts []T
+		e := T(r.IntN(256))
+		for range r.IntN(40) {
+			switch r.IntN(4) {
+			case 0:
+				e = T(r.IntN(256)) // jump to anywhere
+			case 1:
+				e += T(r.IntN(3)) // stay close
+			default:
+				e += T(r.IntN(5)) + 1 // ascending (apart from wrap-around)
+			}
+			if r.IntN(8) == 0 {
+				top := T(r.IntN(256))
+				if r.IntN(2) == 0 {
+					top = endMark
+				}
+				bd.AddRange(e, top)
+				expected.AddRange(e, top)
+				continue
+			}
+			bd.Add(e)
+			expected.Add(e)
+			elts = append(elts, e)
+		}
+		got := bd.Build()
+		Assertf(t, rangeset.Equal(got, expected), "%s Builder: expected %v got %v", name, expected, got)
+
+		s := randomSet[T](r)
+		expected = rangeset.Union(s, rangeset.Make(elts...))
+		unsorted := s.Copy()
+		unsorted.AddSorted(elts)
+		Assertf(t, rangeset.Equal(unsorted, expected), "%s AddSorted(unsorted): expected %v got %v", name, expected, unsorted)
+		slices.Sort(elts)
+		s.AddSorted(elts)
+		Assertf(t, rangeset.Equal(s, expected), "%s AddSorted: expected %v got %v", name, expected, s)
+	}
+}
+
+// TestBuilderBruteForce tests the Builder using 8-bit elements
+func TestBuilderBruteForce(t *testing.T) {
+	testBuilderBruteForce[int8](t, "int8")
+	testBuilderBruteForce[uint8](t, "uint8")
+}
+
+// ascendingElements returns n elements in ascending order with occasional gaps
+func ascendingElements(n int) []BuilderElementType {
+	r := rand.New(rand.NewPCG(39, 40))
+	retval := make([]BuilderElementType, n)
+	var e BuilderElementType
+	for idx := range retval {
+		e += BuilderElementType(r.IntN(3)) + 1
+		retval[idx] = e
+	}
+	return retval
+}
+
+func BenchmarkBuilderAdd(b *testing.B) {
+	elts := ascendingElements(10_000)
+	b.ResetTimer()
+	for range b.N {
+		var bd rangeset.Builder[BuilderElementType]
+		for _, e := range elts {
+			bd.Add(e)
+		}
+		_ = bd.Build()
+	}
+}
+
+func BenchmarkSetAdd(b *testing.B) {
+	elts := ascendingElements(10_000)
+	b.ResetTimer()
+	for range b.N {
+		var s rangeset.Set[BuilderElementType]
+		for _, e := range elts {
+			s.Add(e)
+		}
+	}
+}
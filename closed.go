@@ -0,0 +1,48 @@
+package rangeset
+
+// closed.go implements methods that take ranges using inclusive (closed) bounds [lo, hi] as an
+// alternative to the asymmetric bounds [b, t) used elsewhere.  This avoids having to pass the
+// smallest element (the "end-mark") as t for a range that includes the largest element -
+// eg AddClosed(100, math.MaxUint64) rather than AddRange(100, 0).
+
+// Closed returns the first and last elements of the range (ie inclusive bounds).
+// Unlike Top, the last element is correct for a range that includes the largest element.
+func (v Span[T]) Closed() (first, last T) {
+	return v.Bot, v.Top - 1
+}
+
+// NewClosed creates a new set containing the elements lo to hi (inclusive).
+// If hi < lo then the set is empty.
+func NewClosed[T Element](lo, hi T) Set[T] {
+	if hi < lo {
+		return Set[T]{}
+	}
+	return Set[T]{{lo, hi + 1}} // hi+1 wraps to the end-mark if hi is the largest element
+}
+
+// AddClosed inserts the elements lo to hi (inclusive) into the set (see AddRange).
+// Nothing is added if hi < lo.
+func (s *Set[T]) AddClosed(lo, hi T) {
+	if hi < lo {
+		return
+	}
+	s.AddRange(lo, hi+1)
+}
+
+// DeleteClosed removes the elements lo to hi (inclusive) from the set (see DeleteRange).
+// Nothing is deleted if hi < lo.
+func (s *Set[T]) DeleteClosed(lo, hi T) {
+	if hi < lo {
+		return
+	}
+	s.DeleteRange(lo, hi+1)
+}
+
+// ContainsClosed tests whether the set contains all the elements lo to hi (inclusive).
+// An empty range (hi < lo) is always contained.
+func (s Set[T]) ContainsClosed(lo, hi T) bool {
+	if hi < lo {
+		return true
+	}
+	return s.ContainsRange(lo, hi+1)
+}
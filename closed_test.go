@@ -0,0 +1,79 @@
+package rangeset_test
+
+import (
+	"github.com/andrewwphillips/rangeset"
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+// TestClosedLimits tests the inclusive bounds methods at the ends of 64-bit types
+func TestClosedLimits(t *testing.T) {
+	s := rangeset.NewClosed[uint64](0, math.MaxUint64)
+	Assertf(t, rangeset.Equal(s, rangeset.Universal[uint64]()), "ClosedLimits: NewClosed expected universal got %v", s)
+	Assertf(t, s.ContainsClosed(0, math.MaxUint64), "ClosedLimits: ContainsClosed of universal set failed")
+
+	s.DeleteClosed(10, math.MaxUint64)
+	expected := rangeset.NewFromRange[uint64](0, 10)
+	Assertf(t, rangeset.Equal(s, expected), "ClosedLimits: DeleteClosed expected %v got %v", expected, s)
+	Assertf(t, !s.ContainsClosed(5, math.MaxUint64), "ClosedLimits: ContainsClosed expected false")
+
+	s.AddClosed(math.MaxUint64-1, math.MaxUint64)
+	Assertf(t, s.ContainsClosed(math.MaxUint64-1, math.MaxUint64), "ClosedLimits: ContainsClosed of added range failed")
+	Assertf(t, s.Contains(math.MaxUint64), "ClosedLimits: AddClosed did not add the largest element")
+	first, last := s[len(s)-1].Closed()
+	Assertf(t, first == math.MaxUint64-1 && last == math.MaxUint64, "ClosedLimits: Closed expected %d,%d got %d,%d",
+		uint64(math.MaxUint64-1), uint64(math.MaxUint64), first, last)
+
+	i := rangeset.NewClosed[int64](math.MinInt64, math.MinInt64)
+	first64, last64 := i[0].Closed()
+	Assertf(t, first64 == math.MinInt64 && last64 == math.MinInt64 && i.Len() == 1, "ClosedLimits: NewClosed(min, min) got %v", i)
+	i.AddClosed(math.MaxInt64, math.MaxInt64)
+	Assertf(t, i.Len() == 2 && i.Contains(math.MaxInt64), "ClosedLimits: AddClosed(max, max) got %v", i)
+
+	e := rangeset.NewClosed[int64](5, 4)
+	Assertf(t, e != nil && len(e) == 0, "ClosedLimits: NewClosed(5, 4) expected empty set got %v", e)
+	Assertf(t, e.ContainsClosed(5, 4), "ClosedLimits: ContainsClosed of empty range expected true")
+}
+
+// testClosedBruteForce checks the inclusive bounds methods against brute force calculations
+func testClosedBruteForce[T int8 | uint8](t *testing.T, name string) {
+	r := rand.New(rand.NewPCG(55, 56))
+	for range 200 {
+		s := randomSet[T](r)
+		lo, hi := T(r.IntN(256)), T(r.IntN(256))
+		if r.IntN(4) == 0 {
+			hi = rangeset.Universal[T]()[0].Top - 1 // largest element
+		}
+		in := func(e T) bool { return lo <= e && e <= hi }
+
+		expected := bruteForce(in)
+		got := rangeset.NewClosed(lo, hi)
+		Assertf(t, rangeset.Equal(got, expected), "%s NewClosed: [%d,%d] expected %v got %v", name, lo, hi, expected, got)
+
+		expected = bruteForce(func(e T) bool { return s.Contains(e) || in(e) })
+		got = s.Copy()
+		got.AddClosed(lo, hi)
+		Assertf(t, rangeset.Equal(got, expected), "%s AddClosed: %v [%d,%d] expected %v got %v", name, s, lo, hi, expected, got)
+
+		expected = bruteForce(func(e T) bool { return s.Contains(e) && !in(e) })
+		got = s.Copy()
+		got.DeleteClosed(lo, hi)
+		Assertf(t, rangeset.Equal(got, expected), "%s DeleteClosed: %v [%d,%d] expected %v got %v", name, s, lo, hi, expected, got)
+
+		contains := len(bruteForce(func(e T) bool { return in(e) && !s.Contains(e) })) == 0
+		Assertf(t, s.ContainsClosed(lo, hi) == contains, "%s ContainsClosed: %v [%d,%d] expected %t", name, s, lo, hi, contains)
+
+		for _, v := range s {
+			first, last := v.Closed()
+			Assertf(t, s.Contains(first) && s.Contains(last) && first <= last,
+				"%s Closed: %v range %v gave %d,%d", name, s, v, first, last)
+		}
+	}
+}
+
+// TestClosedBruteForce tests the inclusive bounds methods using 8-bit elements
+func TestClosedBruteForce(t *testing.T) {
+	testClosedBruteForce[int8](t, "int8")
+	testClosedBruteForce[uint8](t, "uint8")
+}
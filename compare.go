@@ -0,0 +1,88 @@
+package rangeset
+
+// compare.go implements methods and functions that test the relationship between sets.
+// These walk the ranges of both sets at once, without creating new sets, so they have
+// time complexity O(r1+r2) (and often finish early) and do not allocate memory.
+
+// IsSubsetOf returns true if every element of s is also in s2
+func (s Set[T]) IsSubsetOf(s2 Set[T]) bool {
+	j := 0
+	for _, v := range s {
+		for j < len(s2) && s2[j].Top-1 < v.Bot {
+			j++ // skip spans of s2 that are entirely below v
+		}
+		// Since spans of a set are never adjacent v must be within a single span of s2
+		if j == len(s2) || v.Bot < s2[j].Bot || v.Top-1 > s2[j].Top-1 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsProperSubsetOf returns true if s is a subset of s2 and s2 has other elements (not in s)
+func (s Set[T]) IsProperSubsetOf(s2 Set[T]) bool {
+	return s.IsSubsetOf(s2) && !equal(s, s2)
+}
+
+// IsSupersetOf returns true if every element of s2 is also in s
+func (s Set[T]) IsSupersetOf(s2 Set[T]) bool {
+	return s2.IsSubsetOf(s)
+}
+
+// IsProperSupersetOf returns true if s is a superset of s2 and s has other elements (not in s2)
+func (s Set[T]) IsProperSupersetOf(s2 Set[T]) bool {
+	return s2.IsProperSubsetOf(s)
+}
+
+// Overlaps returns true if s and s2 have at least one element in common
+func (s Set[T]) Overlaps(s2 Set[T]) bool {
+	found := false
+	intersectSpans(s, s2, func(_, _ T) bool {
+		found = true
+		return false // no need to look any further
+	})
+	return found
+}
+
+// Disjoint returns true if s and s2 have no elements in common
+func (s Set[T]) Disjoint(s2 Set[T]) bool {
+	return !s.Overlaps(s2)
+}
+
+// Compare returns -1, 0 or +1 depending on whether s1 is less than, equal to, or greater than s2.
+// Sets are ordered by comparing their elements in turn (in numeric order) as for slices.Compare
+// - eg {1:2} < {1:2,4} < {1,5} < {2}.  The empty set is less than any other set.
+// Compare can be used with slices.SortFunc, slices.BinarySearchFunc, etc.
+func Compare[T Element](s1, s2 Set[T]) int {
+	for idx := 0; idx < len(s1) && idx < len(s2); idx++ {
+		if s1[idx].Bot != s2[idx].Bot {
+			if s1[idx].Bot < s2[idx].Bot {
+				return -1
+			}
+			return +1
+		}
+		last1, last2 := s1[idx].Top-1, s2[idx].Top-1
+		if last1 == last2 {
+			continue
+		}
+		// If one span finishes first, the other set has the next element (last+1) before it, unless
+		// this was the last span, in which case the set is a prefix of the other (so compares less).
+		if last1 < last2 {
+			if idx == len(s1)-1 {
+				return -1
+			}
+			return +1
+		}
+		if idx == len(s2)-1 {
+			return +1
+		}
+		return -1
+	}
+	switch {
+	case len(s1) < len(s2):
+		return -1
+	case len(s1) > len(s2):
+		return +1
+	}
+	return 0
+}
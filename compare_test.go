@@ -0,0 +1,142 @@
+package rangeset_test
+
+import (
+	"github.com/andrewwphillips/rangeset"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+type CompareElementType int
+
+// compareData is for table-driven tests of the relationship between 2 sets
+var compareData = map[string]struct {
+	s1, s2                  string
+	subset, proper, overlap bool
+	compare                 int
+}{
+	"EmptyEmpty": {"{}", "{}", true, false, false, 0},
+	"EmptyOne":   {"{}", "{1}", true, true, false, -1},
+	"OneEmpty":   {"{1}", "{}", false, false, false, +1},
+	"Same":       {"{1:5,7}", "{1:5,7}", true, false, true, 0},
+	"Inside":     {"{2:4}", "{1:5}", true, true, true, +1},
+	"StartSame":  {"{1:4}", "{1:5}", true, true, true, -1},
+	"EndSame":    {"{2:5}", "{1:5}", true, true, true, +1},
+	"Outside":    {"{1:5}", "{2:4}", false, false, true, -1},
+	"Overlap":    {"{1:5}", "{4:8}", false, false, true, -1},
+	"Touch":      {"{1:5}", "{6:8}", false, false, false, -1},
+	"Prefix":     {"{1:2}", "{1:2,4}", true, true, true, -1},
+	"Gap":        {"{1,5}", "{1:2}", false, false, true, +1},
+	"Spread":     {"{2,4,6}", "{1:7}", true, true, true, +1},
+	"Split":      {"{1:3,5:7}", "{1:7}", true, true, true, +1},
+	"Across":     {"{3:5}", "{1:3,5:7}", false, false, true, +1},
+	"Between":    {"{4}", "{1:3,5:7}", false, false, false, +1},
+	"UAndU":      {"{U}", "{U}", true, false, true, 0},
+	"OneAndU":    {"{1}", "{U}", true, true, true, +1},
+	"UAndOne":    {"{U}", "{1}", false, false, true, -1},
+	"EndAndU":    {"{5:E}", "{U}", true, true, true, +1},
+	"Ends":       {"{E:10}", "{5:E}", false, false, true, -1},
+	"EndsApart":  {"{E:4}", "{5:E}", false, false, false, -1},
+}
+
+// TestCompareTable tests IsSubsetOf, IsProperSubsetOf, Overlaps, Compare etc using the compareData table
+func TestCompareTable(t *testing.T) {
+	for name, data := range compareData {
+		s1, _ := rangeset.NewFromString[CompareElementType](data.s1)
+		s2, _ := rangeset.NewFromString[CompareElementType](data.s2)
+
+		got := s1.IsSubsetOf(s2)
+		Assertf(t, got == data.subset, "IsSubsetOf: %12s: %v of %v: expected %t got %t", name, s1, s2, data.subset, got)
+		got = s2.IsSupersetOf(s1)
+		Assertf(t, got == data.subset, "IsSupersetOf: %12s: %v of %v: expected %t got %t", name, s2, s1, data.subset, got)
+		got = s1.IsProperSubsetOf(s2)
+		Assertf(t, got == data.proper, "IsProperSubsetOf: %12s: %v of %v: expected %t got %t", name, s1, s2, data.proper, got)
+		got = s2.IsProperSupersetOf(s1)
+		Assertf(t, got == data.proper, "IsProperSupersetOf: %12s: %v of %v: expected %t got %t", name, s2, s1, data.proper, got)
+		got = s1.Overlaps(s2)
+		Assertf(t, got == data.overlap, "Overlaps: %12s: %v and %v: expected %t got %t", name, s1, s2, data.overlap, got)
+		got = s2.Disjoint(s1)
+		Assertf(t, got == !data.overlap, "Disjoint: %12s: %v and %v: expected %t got %t", name, s2, s1, !data.overlap, got)
+
+		cmp := rangeset.Compare(s1, s2)
+		Assertf(t, cmp == data.compare, "Compare: %12s: %v and %v: expected %d got %d", name, s1, s2, data.compare, cmp)
+		cmp = rangeset.Compare(s2, s1)
+		Assertf(t, cmp == -data.compare, "Compare: %12s: %v and %v: expected %d got %d", name, s2, s1, -data.compare, cmp)
+	}
+}
+
+// elements returns all the elements of a set of an 8-bit type in numeric order
+func elements[T int8 | uint8](s rangeset.Set[T]) []T {
+	var retval []T
+	for i := 0; i < 256; i++ {
+		if s.Contains(T(i)) {
+			retval = append(retval, T(i))
+		}
+	}
+	slices.Sort(retval)
+	return retval
+}
+
+// testCompareBruteForce compares the results of the relationship methods with brute force calculations
+func testCompareBruteForce[T int8 | uint8](t *testing.T, name string) {
+	r := rand.New(rand.NewPCG(7, 8))
+	for range 2000 {
+		s1, s2 := randomSet[T](r), randomSet[T](r)
+		if r.IntN(4) == 0 {
+			s1 = rangeset.Intersect(s1, s2) // make sure we get some subsets
+		}
+		inter := rangeset.Intersect(s1, s2)
+
+		subset := rangeset.Equal(inter, s1)
+		got := s1.IsSubsetOf(s2)
+		Assertf(t, got == subset, "%s IsSubsetOf: %v of %v: expected %t got %t", name, s1, s2, subset, got)
+		overlap := len(inter) > 0
+		got = s1.Overlaps(s2)
+		Assertf(t, got == overlap, "%s Overlaps: %v and %v: expected %t got %t", name, s1, s2, overlap, got)
+
+		cmp := slices.Compare(elements(s1), elements(s2))
+		gotCmp := rangeset.Compare(s1, s2)
+		Assertf(t, gotCmp == cmp, "%s Compare: %v and %v: expected %d got %d", name, s1, s2, cmp, gotCmp)
+	}
+}
+
+// TestCompareBruteForce tests relationship methods against brute force calculations (using 8-bit elements)
+func TestCompareBruteForce(t *testing.T) {
+	testCompareBruteForce[int8](t, "int8")
+	testCompareBruteForce[uint8](t, "uint8")
+}
+
+// TestCompareAllocs checks that the relationship methods do not allocate memory
+func TestCompareAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocations are not counted reliably with the race detector")
+	}
+	s1, _ := rangeset.NewFromString[CompareElementType]("{1:10,20:30,40:50}")
+	s2, _ := rangeset.NewFromString[CompareElementType]("{1:10,20:30,40:50,60}")
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = s1.IsProperSubsetOf(s2)
+		_ = s1.Overlaps(s2)
+		_ = s1.Disjoint(s2)
+		_ = rangeset.Compare(s1, s2)
+	})
+	Assertf(t, allocs == 0, "CompareAllocs: expected no allocations got %v", allocs)
+}
+
+// TestCompareSort checks that Compare can be used to sort sets
+func TestCompareSort(t *testing.T) {
+	var sets []rangeset.Set[CompareElementType]
+	for _, str := range []string{"{2}", "{1:2,4}", "{}", "{1,5}", "{1:2}"} {
+		s, _ := rangeset.NewFromString[CompareElementType](str)
+		sets = append(sets, s)
+	}
+	slices.SortFunc(sets, rangeset.Compare)
+	got := ""
+	for _, s := range sets {
+		got += s.String()
+	}
+	const expected = "{}{1:2}{1:2,4}{1,5}{2}"
+	Assertf(t, got == expected, "CompareSort: expected %s got %s", expected, got)
+
+	idx, found := slices.BinarySearchFunc(sets, rangeset.Make[CompareElementType](1, 2), rangeset.Compare)
+	Assertf(t, found && idx == 1, "CompareSort: expected to find {1:2} at index 1 got %d (%t)", idx, found)
+}
@@ -0,0 +1,75 @@
+package rangeset
+
+// count.go implements an internal type used to count the elements of sets exactly.
+// A uint64 is not quite big enough since a set of a 64-bit type can have 2^64 elements.
+
+import (
+	"math/big"
+)
+
+// count holds a number of elements as a 128-bit unsigned integer
+type count struct{ hi, lo uint64 }
+
+// addLen adds the number of elements in a range as returned by rangeLen(), where 0 means
+// the range is all the elements of a 64-bit type
+func (c *count) addLen(n uint64) {
+	if n == 0 {
+		c.hi++ // all 2^64 elements of a 64-bit type
+		return
+	}
+	c.add(count{0, n})
+}
+
+// add adds another count to c
+func (c *count) add(c2 count) {
+	var carry uint64
+	if c.lo += c2.lo; c.lo < c2.lo {
+		carry = 1
+	}
+	c.hi += c2.hi + carry
+}
+
+// plus returns c + n
+func (c count) plus(n uint64) count {
+	c.add(count{0, n})
+	return c
+}
+
+// sub returns c - c2 (which must not be negative)
+func (c count) sub(c2 count) count {
+	var borrow uint64
+	if c.lo < c2.lo {
+		borrow = 1
+	}
+	return count{c.hi - c2.hi - borrow, c.lo - c2.lo}
+}
+
+func (c count) isZero() bool {
+	return c.hi == 0 && c.lo == 0
+}
+
+func (c count) less(c2 count) bool {
+	return c.hi < c2.hi || c.hi == c2.hi && c.lo < c2.lo
+}
+
+func (c count) float64() float64 {
+	return float64(c.hi)*(1<<64) + float64(c.lo)
+}
+
+func (c count) bigInt() *big.Int {
+	retval := new(big.Int).SetUint64(c.hi)
+	return retval.Lsh(retval, 64).Or(retval, new(big.Int).SetUint64(c.lo))
+}
+
+// half returns c/2 (rounded down)
+func (c count) half() count {
+	return count{c.hi >> 1, c.hi<<63 | c.lo>>1}
+}
+
+// total returns the exact number of elements in s
+func (s Set[T]) total() (retval count) {
+	for _, v := range s {
+		retval.addLen(rangeLen(v.Bot, v.Top-1))
+	}
+	return
+}
@@ -0,0 +1,97 @@
+package rangeset_test
+
+import (
+	"github.com/andrewwphillips/rangeset"
+	"math/big"
+	"math/rand/v2"
+	"testing"
+)
+
+// twoTo64 is the number of elements of a universal set of a 64-bit type
+var twoTo64 = new(big.Int).Lsh(big.NewInt(1), 64)
+
+// TestLengthBig64 tests the exact counts of sets that have 2^64 (or nearly 2^64) elements
+func TestLengthBig64(t *testing.T) {
+	u := rangeset.Universal[uint64]()
+	s := rangeset.NewFromRange[uint64](10, 20)
+
+	Assertf(t, u.LengthBig().Cmp(twoTo64) == 0, "LengthBig64: universal expected %v got %v", twoTo64, u.LengthBig())
+	hi, lo := u.Length128()
+	Assertf(t, hi == 1 && lo == 0, "LengthBig64: universal Length128 expected 1,0 got %d,%d", hi, lo)
+	hi, lo = s.Length128()
+	Assertf(t, hi == 0 && lo == 10, "LengthBig64: Length128 expected 0,10 got %d,%d", hi, lo)
+	Assertf(t, s.LengthBig().Cmp(big.NewInt(10)) == 0, "LengthBig64: expected 10 got %v", s.LengthBig())
+
+	got := u.CountRangeBig(0, 0) // 0 is the end-mark so this is all elements
+	Assertf(t, got.Cmp(twoTo64) == 0, "LengthBig64: CountRangeBig expected %v got %v", twoTo64, got)
+	got, spans := u.CoverageBig(0, 0)
+	Assertf(t, got.Cmp(twoTo64) == 0 && spans == 1, "LengthBig64: CoverageBig expected %v,1 got %v,%d", twoTo64, got, spans)
+	got = u.Window(0, 0).LengthBig()
+	Assertf(t, got.Cmp(twoTo64) == 0, "LengthBig64: View.LengthBig expected %v got %v", twoTo64, got)
+
+	got, spans = rangeset.UnionLengthBig(u, s)
+	Assertf(t, got.Cmp(twoTo64) == 0 && spans == 1, "LengthBig64: UnionLengthBig expected %v,1 got %v,%d", twoTo64, got, spans)
+	got, spans = rangeset.IntersectionLengthBig(u, u)
+	Assertf(t, got.Cmp(twoTo64) == 0 && spans == 1, "LengthBig64: IntersectionLengthBig expected %v,1 got %v,%d", twoTo64, got, spans)
+	got, spans = rangeset.DifferenceLengthBig(u, s)
+	expected := new(big.Int).Sub(twoTo64, big.NewInt(10))
+	Assertf(t, got.Cmp(expected) == 0 && spans == 2, "LengthBig64: DifferenceLengthBig expected %v,2 got %v,%d", expected, got, spans)
+
+	got = rangeset.Similarity(u, rangeset.Set[uint64]{}).HammingBig()
+	Assertf(t, got.Cmp(twoTo64) == 0, "LengthBig64: HammingBig expected %v got %v", twoTo64, got)
+
+	ix := rangeset.NewIndexed(rangeset.Universal[int64]())
+	Assertf(t, ix.LengthBig().Cmp(twoTo64) == 0, "LengthBig64: Indexed.LengthBig expected %v got %v", twoTo64, ix.LengthBig())
+	hi, lo = ix.Length128()
+	Assertf(t, hi == 1 && lo == 0, "LengthBig64: Indexed.Length128 expected 1,0 got %d,%d", hi, lo)
+	got = ix.CountRangeBig(-1<<63, -1<<63)
+	Assertf(t, got.Cmp(twoTo64) == 0, "LengthBig64: Indexed.CountRangeBig expected %v got %v", twoTo64, got)
+	got = ix.CountRangeBig(-10, 10)
+	Assertf(t, got.Cmp(big.NewInt(20)) == 0, "LengthBig64: Indexed.CountRangeBig expected 20 got %v", got)
+}
+
+// testLengthBigBruteForce checks that the exact counts agree with the normal ones (which can't overflow
+// for 8-bit elements)
+func testLengthBigBruteForce[T int8 | uint8](t *testing.T, name string) {
+	endMark := rangeset.Universal[T]()[0].Top
+	r := rand.New(rand.NewPCG(53, 54))
+	for range 100 {
+		s1, s2 := randomSet[T](r), randomSet[T](r)
+		b, top := T(r.IntN(256)), T(r.IntN(256))
+		if r.IntN(4) == 0 {
+			top = endMark
+		}
+		ix := rangeset.NewIndexed(s1)
+
+		length, _ := s1.Length()
+		hi, lo := s1.Length128()
+		Assertf(t, s1.LengthBig().Uint64() == length && hi == 0 && lo == length,
+			"%s LengthBig: %v expected %d got %v and %d,%d", name, s1, length, s1.LengthBig(), hi, lo)
+		Assertf(t, ix.LengthBig().Uint64() == length, "%s Indexed.LengthBig: %v expected %d got %v", name, s1, length, ix.LengthBig())
+
+		count := s1.CountRange(b, top)
+		Assertf(t, s1.CountRangeBig(b, top).Uint64() == count, "%s CountRangeBig: %v [%d,%d) expected %d got %v",
+			name, s1, b, top, count, s1.CountRangeBig(b, top))
+		Assertf(t, ix.CountRangeBig(b, top).Uint64() == count, "%s Indexed.CountRangeBig: %v [%d,%d) expected %d got %v",
+			name, s1, b, top, count, ix.CountRangeBig(b, top))
+
+		for op, f := range map[string]func(s1, s2 rangeset.Set[T]) (uint64, int){"Intersection": rangeset.IntersectionLength[T],
+			"Union": rangeset.UnionLength[T], "Difference": rangeset.DifferenceLength[T]} {
+			fBig := map[string]func(s1, s2 rangeset.Set[T]) (*big.Int, int){"Intersection": rangeset.IntersectionLengthBig[T],
+				"Union": rangeset.UnionLengthBig[T], "Difference": rangeset.DifferenceLengthBig[T]}[op]
+			length, spans := f(s1, s2)
+			got, gotSpans := fBig(s1, s2)
+			Assertf(t, got.Uint64() == length && gotSpans == spans, "%s %sLengthBig: %v %v expected %d,%d got %v,%d",
+				name, op, s1, s2, length, spans, got, gotSpans)
+		}
+
+		m := rangeset.Similarity(s1, s2)
+		Assertf(t, m.HammingBig().Uint64() == m.Hamming(), "%s HammingBig: %v %v expected %d got %v", name, s1, s2, m.Hamming(), m.HammingBig())
+	}
+}
+
+// TestLengthBigBruteForce tests the exact counts using 8-bit elements
+func TestLengthBigBruteForce(t *testing.T) {
+	testLengthBigBruteForce[int8](t, "int8")
+	testLengthBigBruteForce[uint8](t, "uint8")
+}
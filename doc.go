@@ -51,9 +51,11 @@
 //
 // Operations (see also AddSet above, which performs a set union)
 //
-//  b := rangeset.Equal(s, s2)         // returns true if 2 sets are identical
+//  b := rangeset.Equal(s, s2, ...)    // returns true if all the sets are identical
 //  s := rangeset.Union(s1, s2, ...)   // returns a new set that is the union of 1 or more sets
 //  s := rangeset.Intersect(s1,s2,...) // returns a set that's the intersection of 1 or more sets
+//  s := rangeset.Difference(s1,s2...) // returns the elements of s1 not in any of the other sets
+//  s := rangeset.SymmetricDifference(s1, s2, ...) // returns elements in an odd number of sets
 //  s2 := rangeset.Complement(s1)      // returns the inverse of a set
 //
 // Iterating (see also Values above, which returns a slice of all elements)
@@ -74,6 +74,21 @@ func TestNotEqual(t *testing.T) {
 	}
 }
 
+// TestEqualMany tests the Equal() function with zero, one and more than two sets
+func TestEqualMany(t *testing.T) {
+	for name, data := range notEqualData {
+		set1, set2 := rangeset.Make(data.left...), rangeset.Make(data.right...)
+		Assertf(t, rangeset.Equal(set1), "%20s: Expecting Equal() on one set to return true", name)
+		Assertf(t, rangeset.Equal(set1, set1.Copy(), set1), "%20s: Expecting Equal() on 3 copies of %s to return true",
+			name, set1)
+		Assertf(t, !rangeset.Equal(set1, set1, set2), "%20s: Expecting Equal() on (%s, %s, %s) to return false",
+			name, set1, set1, set2)
+		Assertf(t, !rangeset.Equal(set2, set1, set2), "%20s: Expecting Equal() on (%s, %s, %s) to return false",
+			name, set2, set1, set2)
+	}
+	Assertf(t, rangeset.Equal[EqualElementType](), "Expecting Equal() with no sets to return true")
+}
+
 // TestRoundTripLeft uses the first (left) set of the notEqualData table (above) to perform round trip tests, by converting
 // to a string then back to a set.  This provides extra tests of Equal() (as well as String() and NewFromString()).
 func TestRoundTripLeft(t *testing.T) {
@@ -0,0 +1,92 @@
+package rangeset
+
+// errors.go declares the errors returned by functions and methods of the package.  Use
+// errors.Is to check for the sentinel errors and errors.As to obtain a *ParseError.
+
+import (
+	"errors"
+	"strconv"
+)
+
+var (
+	// ErrInvalidRange is returned when the top of a range is not above the bottom (see TryAddRange)
+	// or the size of a block of elements is not positive (see Quotient)
+	ErrInvalidRange = errors.New("rangeset: invalid range")
+
+	// ErrInvalidSet is returned when the ranges of a set are out of order, overlap or are adjacent (see Validate)
+	ErrInvalidSet = errors.New("rangeset: invalid set")
+
+	// ErrOverflow is returned when a value is outside the range of the element type - eg when
+	// a transform of a set overflows and the policy is OverflowError
+	ErrOverflow = errors.New("rangeset: element overflow")
+
+	// ErrTooLarge is returned when the result of an operation would have too many ranges to
+	// be stored - eg when scaling a set with a huge number of elements (see Scale)
+	ErrTooLarge = errors.New("rangeset: result too large")
+)
+
+// ParseError is returned by NewFromString when the string is not a valid encoding of a set
+type ParseError struct {
+	Input string // the string being parsed
+	Msg   string // describes the problem
+	Err   error  // underlying error (eg from strconv or ErrInvalidRange) or nil
+}
+
+func (e *ParseError) Error() string {
+	retval := "rangeset: " + e.Msg + " in set " + e.Input
+	if e.Err != nil {
+		retval += ": " + e.Err.Error()
+	}
+	return retval
+}
+
+// Unwrap returns the underlying error, so that errors.Is and errors.As can be used
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError is returned by the Validate method when a set is invalid
+type ValidationError struct {
+	Index int    // index of the first invalid range of the set
+	Msg   string // describes the problem
+	Err   error  // ErrInvalidRange (empty range) or ErrInvalidSet
+}
+
+func (e *ValidationError) Error() string {
+	return "rangeset: " + e.Msg + " at index " + strconv.Itoa(e.Index)
+}
+
+// Unwrap returns the underlying error, so that errors.Is can be used
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// TryNewFromRange is like NewFromRange but returns ErrInvalidRange if t <= b.  As for AddRange
+// (but unlike NewFromRange) t may be the smallest element (end-mark) to include the largest.
+func TryNewFromRange[T Element](b, t T) (Set[T], error) {
+	var endMark = minInt[T]() // indicates top/bottom of range of valid elements
+	if t <= b && t != endMark {
+		return nil, ErrInvalidRange
+	}
+	return Set[T]{{b, t}}, nil
+}
+
+// TryAddRange is like AddRange but returns ErrInvalidRange (and does not modify the set) if t <= b
+func (s *Set[T]) TryAddRange(b, t T) error {
+	var endMark = minInt[T]() // indicates top/bottom of range of valid elements
+	if t <= b && t != endMark {
+		return ErrInvalidRange
+	}
+	s.AddRange(b, t)
+	return nil
+}
+
+// TryDeleteRange is like DeleteRange but returns ErrInvalidRange (and does not modify the set) if t <= b
+func (s *Set[T]) TryDeleteRange(b, t T) error {
+	var endMark = minInt[T]() // indicates top/bottom of range of valid elements
+	if t <= b && t != endMark {
+		return ErrInvalidRange
+	}
+	s.DeleteRange(b, t)
+	return nil
+}
@@ -0,0 +1,117 @@
+package rangeset_test
+
+import (
+	"errors"
+	"github.com/andrewwphillips/rangeset"
+	"strconv"
+	"testing"
+)
+
+type ErrorsElementType int8
+
+var tryData = map[string]struct {
+	in       string
+	b, t     ErrorsElementType
+	invalid  bool
+	expAdd   string
+	expDel   string
+	expRange string
+}{
+	"Empty":       {"{}", 1, 3, false, "{1:2}", "{}", "{1:2}"},
+	"Swapped":     {"{1:5}", 3, 1, true, "{1:5}", "{1:5}", "{}"},
+	"Zero":        {"{1:5}", 3, 3, true, "{1:5}", "{1:5}", "{}"},
+	"Delete":      {"{1:5}", 2, 4, false, "{1:5}", "{1,4:5}", "{2:3}"},
+	"ToEnd":       {"{1:5}", 100, -128, false, "{1:5,100:127}", "{1:5}", "{100:127}"},
+	"ToEndDelete": {"{1:5,100:127}", 101, -128, false, "{1:5,100:127}", "{1:5,100}", "{101:127}"},
+	"MinSwapped":  {"{-128:0}", 0, -127, true, "{-128:0}", "{-128:0}", "{}"},
+}
+
+// TestTry checks that the checked variants of AddRange, DeleteRange and NewFromRange
+// return ErrInvalidRange (without changing the set) when t <= b
+func TestTry(t *testing.T) {
+	for name, data := range tryData {
+		s, _ := rangeset.NewFromString[ErrorsElementType](data.in)
+		err := s.TryAddRange(data.b, data.t)
+		Assertf(t, errors.Is(err, rangeset.ErrInvalidRange) == data.invalid, "TryAdd: %12s: expected invalid %v got %v", name, data.invalid, err)
+		Assertf(t, s.String() == data.expAdd, "TryAdd: %12s: expected %q got %q", name, data.expAdd, s.String())
+
+		s, _ = rangeset.NewFromString[ErrorsElementType](data.in)
+		err = s.TryDeleteRange(data.b, data.t)
+		Assertf(t, errors.Is(err, rangeset.ErrInvalidRange) == data.invalid, "TryDel: %12s: expected invalid %v got %v", name, data.invalid, err)
+		Assertf(t, s.String() == data.expDel, "TryDel: %12s: expected %q got %q", name, data.expDel, s.String())
+
+		r, err := rangeset.TryNewFromRange(data.b, data.t)
+		Assertf(t, errors.Is(err, rangeset.ErrInvalidRange) == data.invalid, "TryNew: %12s: expected invalid %v got %v", name, data.invalid, err)
+		Assertf(t, r.String() == data.expRange, "TryNew: %12s: expected %q got %q", name, data.expRange, r.String())
+	}
+}
+
+var parseErrorData = map[string]struct {
+	in       string
+	expected error // sentinel error wrapped by the ParseError (nil if none)
+	numError bool  // wraps a *strconv.NumError
+}{
+	"NoBraces":      {"1:2", nil, false},
+	"TooManyParts":  {"{1:2:3}", nil, false},
+	"BadStart":      {"{x:3}", nil, true},
+	"BadEnd":        {"{1:y}", nil, true},
+	"BadValue":      {"{1,z}", nil, true},
+	"RangeLess":     {"{2:1}", rangeset.ErrInvalidRange, false},
+	"ValueOverflow": {"{128}", rangeset.ErrOverflow, false},
+	"StartOverflow": {"{-129:0}", rangeset.ErrOverflow, false},
+	"EndOverflow":   {"{0:200}", rangeset.ErrOverflow, false},
+}
+
+// TestParseError checks that NewFromString returns a *ParseError that wraps the cause
+func TestParseError(t *testing.T) {
+	for name, data := range parseErrorData {
+		_, err := rangeset.NewFromString[ErrorsElementType](data.in)
+		var pe *rangeset.ParseError
+		Assertf(t, errors.As(err, &pe), "ParseError: %14s: expected *ParseError got %v", name, err)
+		if pe == nil {
+			continue
+		}
+		Assertf(t, pe.Input != "", "ParseError: %14s: expected input to be set", name)
+		if data.expected != nil {
+			Assertf(t, errors.Is(err, data.expected), "ParseError: %14s: expected %v got %v", name, data.expected, err)
+		}
+		var ne *strconv.NumError
+		Assertf(t, errors.As(err, &ne) == data.numError, "ParseError: %14s: expected NumError %v got %v", name, data.numError, err)
+	}
+}
+
+var parseOverflowData = map[string]struct {
+	in     string
+	signed bool // parse as int64 (else uint64)
+}{
+	"Uint64":        {"{18446744073709551616}", false},
+	"Uint64Huge":    {"{99999999999999999999}", false},
+	"Uint64Start":   {"{99999999999999999999:1}", false},
+	"Uint64End":     {"{1:99999999999999999999}", false},
+	"Int64":         {"{9223372036854775808}", true},
+	"Int64Negative": {"{-99999999999999999999}", true},
+	"Int64Start":    {"{-9223372036854775809:0}", true},
+	"Int64End":      {"{0:99999999999999999999}", true},
+}
+
+// TestParseOverflow checks that values too big for 64 bits give a *ParseError wrapping ErrOverflow
+func TestParseOverflow(t *testing.T) {
+	for name, data := range parseOverflowData {
+		var err error
+		if data.signed {
+			_, err = rangeset.NewFromString[int64](data.in)
+		} else {
+			_, err = rangeset.NewFromString[uint64](data.in)
+		}
+		var pe *rangeset.ParseError
+		Assertf(t, errors.As(err, &pe) && pe.Input == data.in, "ParseOverflow: %14s: expected *ParseError for %q got %v", name, data.in, err)
+		Assertf(t, errors.Is(err, rangeset.ErrOverflow), "ParseOverflow: %14s: expected ErrOverflow got %v", name, err)
+	}
+}
+
+// TestOverflowError checks that transforms report ErrOverflow
+func TestOverflowError(t *testing.T) {
+	s := rangeset.Make[ErrorsElementType](100)
+	err := s.Shift(100, rangeset.OverflowError)
+	Assertf(t, errors.Is(err, rangeset.ErrOverflow), "OverflowError: expected ErrOverflow got %v", err)
+}
@@ -0,0 +1,131 @@
+package rangeset
+
+// filter.go implements methods that remove elements (or ranges) from a set using a function
+// to decide which to keep.  See also the Filter method (in traverse.go).
+
+import (
+	"math"
+	"runtime"
+	"sync"
+)
+
+// FilterSpans calls f on every range of the set, replacing the range with the ranges that f
+// returns.  Typically, f returns nil (to delete the range), the range itself (to keep it) or
+// part(s) of the range.  Elements of the returned ranges that are not in the original range are
+// ignored.  Since f is only called once per range it has time complexity O(r log r).
+func (s *Set[T]) FilterSpans(f func(Span[T]) []Span[T]) {
+	var endMark = minInt[T]() // in a range it flags: bottom/top of all valid elements
+	retval := make([]Span[T], 0, len(*s))
+	for _, v := range *s {
+		for _, r := range f(v) {
+			// Trim the returned range to the original range, ignoring empty ranges
+			if r.Top <= r.Bot && r.Top != endMark {
+				continue
+			}
+			b, last := max(r.Bot, v.Bot), min(r.Top-1, v.Top-1)
+			if b <= last {
+				retval = append(retval, Span[T]{b, last + 1})
+			}
+		}
+	}
+	*s = normalize(retval)
+}
+
+// RetainFunc deletes the elements of the set for which f returns false.  The result is built in
+// a single pass (of every element) so it has time complexity O(n).
+func (s *Set[T]) RetainFunc(f func(T) bool) {
+	*s = retain(*s, f)
+}
+
+// ParallelFilter is like RetainFunc but calls f from (up to) n goroutines at once, by splitting
+// the elements of the set into n parts of about the same size.  Note that f must be safe to call
+// concurrently.  If n <= 0 then runtime.GOMAXPROCS(0) goroutines are used.
+func (s *Set[T]) ParallelFilter(n int, f func(T) bool) {
+	if n <= 0 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	parts := s.split(n)
+	if len(parts) < 2 {
+		s.RetainFunc(f)
+		return
+	}
+
+	partial := make([]Set[T], len(parts))
+	var wg sync.WaitGroup
+	for i := range parts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			partial[i] = retain(parts[i], f)
+		}()
+	}
+	wg.Wait()
+
+	// Join the results (in order) - the last range of one may be adjacent to the first of the next
+	retval := make(Set[T], 0, len(*s))
+	for _, p := range partial {
+		for _, v := range p {
+			retval = appendSpan(retval, v.Bot, v.Top-1)
+		}
+	}
+	*s = retval
+}
+
+// retain returns a new set of the elements of s for which f returns true
+func retain[T Element](s Set[T], f func(T) bool) Set[T] {
+	retval := Set[T]{}
+	for _, v := range s {
+		v.walk(func(e T) bool {
+			if f(e) {
+				retval = appendSpan(retval, e, e)
+			}
+			return true
+		})
+	}
+	return retval
+}
+
+// split divides the elements of the set into (up to) n parts, with about the same number of
+// elements in each part.  The parts share the ranges of the set except where a range is split.
+func (s Set[T]) split(n int) []Set[T] {
+	// Work out the number of elements in each part (rounded up)
+	var size uint64
+	if total := s.total(); total.hi > 0 {
+		size = math.MaxUint64/uint64(n) + 1 // total is 2^64
+	} else {
+		size = total.lo / uint64(n)
+		if total.lo%uint64(n) != 0 {
+			size++
+		}
+	}
+	if size == 0 {
+		return nil
+	}
+
+	retval := make([]Set[T], 0, n)
+	var part Set[T]
+	remaining := size // number of elements still to be added to the current part
+	for _, v := range s {
+		for {
+			if length := rangeLen(v.Bot, v.Top-1); length != 0 && length <= remaining {
+				part = append(part, v) // all of (what remains of) v fits
+				remaining -= length
+				break
+			}
+			// Split v - the first remaining elements complete the current part
+			mid := v.Bot + T(remaining)
+			part = append(part, Span[T]{v.Bot, mid})
+			retval = append(retval, part)
+			part, remaining = nil, size
+			v.Bot = mid
+		}
+		if remaining == 0 {
+			retval = append(retval, part)
+			part, remaining = nil, size
+		}
+	}
+	if len(part) > 0 {
+		retval = append(retval, part)
+	}
+	return retval
+}
@@ -0,0 +1,111 @@
+package rangeset_test
+
+import (
+	"github.com/andrewwphillips/rangeset"
+	"math/rand/v2"
+	"testing"
+)
+
+type FilterElementType int
+
+// filterSpansData is for table-driven tests of FilterSpans
+var filterSpansData = map[string]struct {
+	in       string
+	f        func(rangeset.Span[FilterElementType]) []rangeset.Span[FilterElementType]
+	expected string
+}{
+	"KeepAll": {"{1:3,5,7:E}", func(v rangeset.Span[FilterElementType]) []rangeset.Span[FilterElementType] {
+		return []rangeset.Span[FilterElementType]{v}
+	}, "{1:3,5,7:E}"},
+	"DeleteAll": {"{1:3,5,7:9}", func(v rangeset.Span[FilterElementType]) []rangeset.Span[FilterElementType] {
+		return nil
+	}, "{}"},
+	"KeepLong": {"{1:3,5,7:9,12:13}", func(v rangeset.Span[FilterElementType]) []rangeset.Span[FilterElementType] {
+		if v.Top-v.Bot < 3 {
+			return nil
+		}
+		return []rangeset.Span[FilterElementType]{v}
+	}, "{1:3,7:9}"},
+	"TrimEnds": {"{1:3,5,7:10}", func(v rangeset.Span[FilterElementType]) []rangeset.Span[FilterElementType] {
+		return []rangeset.Span[FilterElementType]{{v.Bot + 1, v.Top - 1}}
+	}, "{2,8:9}"},
+	"Split": {"{1:10}", func(v rangeset.Span[FilterElementType]) []rangeset.Span[FilterElementType] {
+		return []rangeset.Span[FilterElementType]{{7, 9}, {v.Bot, v.Bot + 2}, {5, 5}}
+	}, "{1:2,7:8}"},
+	"Outside": {"{1:3,10:12}", func(v rangeset.Span[FilterElementType]) []rangeset.Span[FilterElementType] {
+		return []rangeset.Span[FilterElementType]{{v.Bot - 5, v.Bot + 1}, {100, 200}}
+	}, "{1,10}"},
+}
+
+// TestFilterSpansTable tests FilterSpans using the filterSpansData table
+func TestFilterSpansTable(t *testing.T) {
+	for name, data := range filterSpansData {
+		s, _ := rangeset.NewFromString[FilterElementType](data.in)
+		expected, _ := rangeset.NewFromString[FilterElementType](data.expected)
+		s.FilterSpans(data.f)
+		Assertf(t, rangeset.Equal(s, expected), "FilterSpans: %12s: expected %v got %v", name, expected, s)
+	}
+}
+
+// testFilterBruteForce checks RetainFunc, Filter and ParallelFilter against brute force calculations
+func testFilterBruteForce[T int8 | uint8](t *testing.T, name string) {
+	r := rand.New(rand.NewPCG(49, 50))
+	for range 100 {
+		s := randomSet[T](r)
+		if r.IntN(10) == 0 {
+			s = rangeset.Universal[T]()
+		}
+		mod, rem := T(r.IntN(5)+1), T(r.IntN(3))
+		f := func(e T) bool { return e%mod != rem }
+		expected := bruteForce(func(e T) bool { return s.Contains(e) && f(e) })
+
+		got := s.Copy()
+		got.RetainFunc(f)
+		Assertf(t, rangeset.Equal(got, expected), "%s RetainFunc: %v expected %v got %v", name, s, expected, got)
+		got = s.Copy()
+		got.Filter(f)
+		Assertf(t, rangeset.Equal(got, expected), "%s Filter: %v expected %v got %v", name, s, expected, got)
+		n := r.IntN(10) - 1
+		got = s.Copy()
+		got.ParallelFilter(n, f)
+		Assertf(t, rangeset.Equal(got, expected), "%s ParallelFilter(%d): %v expected %v got %v", name, n, s, expected, got)
+	}
+}
+
+// TestFilterBruteForce tests RetainFunc, Filter and ParallelFilter using 8-bit elements
+func TestFilterBruteForce(t *testing.T) {
+	testFilterBruteForce[int8](t, "int8")
+	testFilterBruteForce[uint8](t, "uint8")
+}
+
+// TestParallelFilterLarge tests ParallelFilter on a set with a few large ranges
+func TestParallelFilterLarge(t *testing.T) {
+	s := rangeset.NewFromRange[FilterElementType](-100_000, 100_000)
+	s.AddRange(200_000, 200_010)
+	s.AddRange(300_000, 500_000)
+	f := func(e FilterElementType) bool { return e%7 != 0 }
+	for _, n := range []int{1, 2, 3, 8, 1000} {
+		expected, got := s.Copy(), s.Copy()
+		expected.RetainFunc(f)
+		got.ParallelFilter(n, f)
+		Assertf(t, rangeset.Equal(got, expected), "ParallelFilterLarge(%d): expected %d ranges got %d", n, len(expected), len(got))
+	}
+}
+
+func BenchmarkRetainFunc(b *testing.B) {
+	s := rangeset.NewFromRange[FilterElementType](0, 1_000_000)
+	f := func(e FilterElementType) bool { return e%1000 != 0 }
+	for range b.N {
+		got := s.Copy()
+		got.RetainFunc(f)
+	}
+}
+
+func BenchmarkParallelFilter(b *testing.B) {
+	s := rangeset.NewFromRange[FilterElementType](0, 1_000_000)
+	f := func(e FilterElementType) bool { return e%1000 != 0 }
+	for range b.N {
+		got := s.Copy()
+		got.ParallelFilter(0, f)
+	}
+}
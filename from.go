@@ -0,0 +1,88 @@
+package rangeset
+
+// from.go implements functions that create sets from many elements (or ranges) at once.
+// Rather than adding elements one at a time the elements are sorted and then the ranges
+// are built in a single pass, so they have time complexity O(n log n).
+
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// FromSlice creates a new set containing the elements of a slice (which is not modified).
+// The elements can be in any order and may contain duplicates.
+func FromSlice[T Element](elems []T) Set[T] {
+	return fromSorted(slices.Sorted(slices.Values(elems)))
+}
+
+// FromMap creates a new set containing the keys of a map
+func FromMap[T Element](m map[T]struct{}) Set[T] {
+	return fromSorted(slices.Sorted(maps.Keys(m)))
+}
+
+// FromSeq creates a new set containing the elements obtained from a Go 1.23 iterator
+func FromSeq[T Element](seq iter.Seq[T]) Set[T] {
+	return fromSorted(slices.Sorted(seq))
+}
+
+// FromSpans creates a new set containing the elements of the ranges obtained from a Go 1.23
+// iterator - ie it is the inverse of the SpansSeq method.  The ranges can be in any order and
+// may overlap or be adjacent to each other.  Empty ranges (where Top <= Bot) are ignored.
+func FromSpans[T Element](seq iter.Seq[Span[T]]) Set[T] {
+	return normalize(slices.Collect(seq))
+}
+
+// NewFromSpans creates a new set from ranges in any order (which may overlap or be adjacent
+// to each other).  Empty ranges are ignored.  The slice of ranges is not modified.
+// (If the ranges are known to be valid a conversion is faster - ie Set[T](spans).)
+func NewFromSpans[T Element](spans ...Span[T]) Set[T] {
+	return normalize(slices.Clone(spans))
+}
+
+// Collect is the same as FromSeq - it is provided for consistency with slices.Collect
+func Collect[T Element](seq iter.Seq[T]) Set[T] {
+	return FromSeq(seq)
+}
+
+// Insert adds the elements obtained from a Go 1.23 iterator to the set - cf. maps.Insert
+func Insert[T Element](s *Set[T], seq iter.Seq[T]) {
+	s.AddSet(FromSeq(seq))
+}
+
+// fromSorted creates a new set from a slice of elements that is in ascending order
+func fromSorted[T Element](sorted []T) Set[T] {
+	retval := Set[T]{}
+	for idx, e := range sorted {
+		if idx > 0 && e == sorted[idx-1] {
+			continue // skip duplicates
+		}
+		retval = appendSpan(retval, e, e)
+	}
+	return retval
+}
+
+// normalize turns a slice of ranges that may be out of order, overlapping, adjacent or empty
+// into a valid set.  The result uses the same memory as spans (so spans is overwritten).
+func normalize[T Element](spans []Span[T]) Set[T] {
+	var endMark = minInt[T]()
+	spans = slices.DeleteFunc(spans, func(v Span[T]) bool { return v.Top <= v.Bot && v.Top != endMark })
+	slices.SortFunc(spans, func(v1, v2 Span[T]) int { return cmp.Compare(v1.Bot, v2.Bot) })
+
+	maxElt := maxInt[T]()
+	retval := Set[T](spans[:0])
+	for _, v := range spans {
+		if n := len(retval); n > 0 {
+			if last := retval[n-1].Top - 1; last == maxElt || v.Bot <= last+1 {
+				// Overlaps or is adjacent to the previous range so just extend it
+				if v.Top-1 > last {
+					retval[n-1].Top = v.Top
+				}
+				continue
+			}
+		}
+		retval = append(retval, v)
+	}
+	return retval
+}
@@ -0,0 +1,118 @@
+package rangeset_test
+
+import (
+	"github.com/andrewwphillips/rangeset"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+type FromElementType int
+
+// fromData is for table-driven tests of the functions that create a set from many elements
+var fromData = map[string]struct {
+	elts     []FromElementType
+	expected string
+}{
+	"Empty":     {nil, "{}"},
+	"One":       {[]FromElementType{42}, "{42}"},
+	"Unsorted":  {[]FromElementType{5, 1, 3, 2}, "{1:3,5}"},
+	"Dupes":     {[]FromElementType{7, 7, 1, 7, 1}, "{1,7}"},
+	"Negative":  {[]FromElementType{-1, 1, 0, -3}, "{-3,-1:1}"},
+	"Extremes":  {[]FromElementType{1<<63 - 1, -1 << 63, 1<<63 - 2}, "{-9223372036854775808,9223372036854775806:E}"},
+	"TwoRanges": {[]FromElementType{10, 4, 11, 5, 12, 6}, "{4:6,10:12}"},
+}
+
+// TestFromTable tests FromSlice, FromMap, FromSeq, Collect and Insert using the fromData table
+func TestFromTable(t *testing.T) {
+	for name, data := range fromData {
+		expected, _ := rangeset.NewFromString[FromElementType](data.expected)
+		orig := slices.Clone(data.elts)
+
+		got := rangeset.FromSlice(data.elts)
+		Assertf(t, rangeset.Equal(got, expected), "FromSlice: %12s: expected %v got %v", name, expected, got)
+		Assertf(t, slices.Equal(data.elts, orig), "FromSlice: %12s: slice was modified to %v", name, data.elts)
+
+		m := make(map[FromElementType]struct{})
+		for _, e := range data.elts {
+			m[e] = struct{}{}
+		}
+		got = rangeset.FromMap(m)
+		Assertf(t, rangeset.Equal(got, expected), "FromMap: %12s: expected %v got %v", name, expected, got)
+
+		got = rangeset.FromSeq(slices.Values(data.elts))
+		Assertf(t, rangeset.Equal(got, expected), "FromSeq: %12s: expected %v got %v", name, expected, got)
+		got = rangeset.Collect(maps.Keys(m))
+		Assertf(t, rangeset.Equal(got, expected), "Collect: %12s: expected %v got %v", name, expected, got)
+
+		got = rangeset.Make[FromElementType](-10, 1, 8)
+		rangeset.Insert(&got, slices.Values(data.elts))
+		expected.AddSet(rangeset.Make[FromElementType](-10, 1, 8))
+		Assertf(t, rangeset.Equal(got, expected), "Insert: %12s: expected %v got %v", name, expected, got)
+	}
+}
+
+// fromSpansData is for table-driven tests of FromSpans
+var fromSpansData = map[string]struct {
+	in       []rangeset.Span[FromElementType]
+	expected string
+}{
+	"Empty":      {nil, "{}"},
+	"EmptySpans": {[]rangeset.Span[FromElementType]{{3, 3}, {5, 1}}, "{}"},
+	"One":        {[]rangeset.Span[FromElementType]{{1, 5}}, "{1:4}"},
+	"Unsorted":   {[]rangeset.Span[FromElementType]{{10, 12}, {1, 5}}, "{1:4,10:11}"},
+	"Adjacent":   {[]rangeset.Span[FromElementType]{{5, 10}, {1, 5}}, "{1:9}"},
+	"Overlap":    {[]rangeset.Span[FromElementType]{{1, 5}, {3, 8}, {2, 4}}, "{1:7}"},
+	"Contained":  {[]rangeset.Span[FromElementType]{{1, 10}, {3, 4}, {12, 13}}, "{1:9,12}"},
+	"ToEnd":      {[]rangeset.Span[FromElementType]{{100, -1 << 63}, {1, 200}}, "{1:E}"},
+	"Universal":  {[]rangeset.Span[FromElementType]{{5, 6}, {-1 << 63, -1 << 63}}, "{U}"},
+}
+
+// TestFromSpansTable tests FromSpans using the fromSpansData table
+func TestFromSpansTable(t *testing.T) {
+	for name, data := range fromSpansData {
+		expected, _ := rangeset.NewFromString[FromElementType](data.expected)
+		orig := slices.Clone(data.in)
+		got := rangeset.FromSpans(slices.Values(data.in))
+		Assertf(t, rangeset.Equal(got, expected), "FromSpans: %12s: expected %v got %v", name, expected, got)
+		Assertf(t, slices.Equal(data.in, orig), "FromSpans: %12s: ranges were modified to %v", name, data.in)
+
+		// FromSpans should be the inverse of SpansSeq
+		got = rangeset.FromSpans(expected.SpansSeq())
+		Assertf(t, rangeset.Equal(got, expected), "FromSpans(SpansSeq): %12s: expected %v got %v", name, expected, got)
+	}
+}
+
+// testFromBruteForce checks FromSlice and FromSpans against adding elements and ranges one at a time
+func testFromBruteForce[T int8 | uint8](t *testing.T, name string) {
+	endMark := rangeset.Universal[T]()[0].Top
+	r := rand.New(rand.NewPCG(35, 36))
+	for range 200 {
+		var elts []T
+		var spans []rangeset.Span[T]
+		var expectedElts, expectedSpans rangeset.Set[T]
+		for range r.IntN(20) {
+			e := T(r.IntN(256))
+			elts = append(elts, e)
+			expectedElts.Add(e)
+
+			v := rangeset.Span[T]{T(r.IntN(256)), T(r.IntN(256))}
+			if r.IntN(8) == 0 {
+				v.Top = endMark
+			}
+			spans = append(spans, v)
+			expectedSpans.AddRange(v.Bot, v.Top)
+		}
+		got := rangeset.FromSlice(elts)
+		Assertf(t, rangeset.Equal(got, expectedElts), "%s FromSlice: %v: expected %v got %v", name, elts, expectedElts, got)
+		got = rangeset.FromSpans(slices.Values(spans))
+		Assertf(t, rangeset.Equal(got, expectedSpans), "%s FromSpans: %v: expected %v got %v", name, spans, expectedSpans, got)
+	}
+}
+
+// TestFromBruteForce tests FromSlice and FromSpans using 8-bit elements
+func TestFromBruteForce(t *testing.T) {
+	testFromBruteForce[int8](t, "int8")
+	testFromBruteForce[uint8](t, "uint8")
+}
@@ -0,0 +1,76 @@
+package rangeset
+
+// gaps.go implements methods that find the "gaps" in a set - ie ranges of elements that
+// are not in the set - within a window of elements [b, t) (using asymmetric bounds).
+// Like a set's ranges, the Top of a gap that extends to the largest element is the end-mark.
+
+import (
+	"iter"
+)
+
+// GapsSeq returns a Go 1.23 iterator of the ranges of elements within [b, t) that are not in
+// the set, in order.  The iterator finds the first gap with a binary search and does not
+// allocate memory, so it has time complexity O(log r + k) where k is the number of gaps.
+func (s Set[T]) GapsSeq(b, t T) iter.Seq[Span[T]] {
+	return func(yield func(Span[T]) bool) {
+		s.gaps(b, t, yield)
+	}
+}
+
+// gaps calls yield on each gap in the set within [b, t), stopping if yield returns false
+func (s Set[T]) gaps(b, t T, yield func(Span[T]) bool) {
+	var endMark = minInt[T]() // in a range it flags: bottom/top of all valid elements
+	if t <= b && t != endMark {
+		return // empty window
+	}
+	last := t - 1 // last element of the window
+	idx := s.bsearch(b)
+	if idx > 0 && b <= s[idx-1].Top-1 {
+		// b is in a range so the first gap (if any) starts after it
+		if s[idx-1].Top-1 >= last {
+			return
+		}
+		b = s[idx-1].Top
+	}
+	for ; idx < len(s) && s[idx].Bot <= last; idx++ {
+		if !yield(Span[T]{b, s[idx].Bot}) || s[idx].Top-1 >= last {
+			return
+		}
+		b = s[idx].Top
+	}
+	yield(Span[T]{b, t})
+}
+
+// FirstGap returns the first range of elements not in the set (starting at from or above) that has
+// at least minLen elements.  This is useful for finding a free block of values (eg to allocate).
+// The returned range starts at from if from is not in the set.  It returns false if there is
+// no such gap.  It has time complexity O(log r + k) where k is the number of gaps checked.
+func (s Set[T]) FirstGap(from T, minLen uint64) (retval Span[T], found bool) {
+	s.gaps(from, minInt[T](), func(gap Span[T]) bool {
+		if n := rangeLen(gap.Bot, gap.Top-1); n == 0 || n >= minLen { // n == 0 means 2^64 elements
+			retval, found = gap, true
+			return false
+		}
+		return true
+	})
+	return
+}
+
+// LargestGap returns the largest range of elements within [b, t) that are not in the set.  If
+// there is more than one largest gap the first is returned.  It returns false if there are no
+// gaps.  It has time complexity O(log r + k) where k is the number of ranges within [b, t).
+func (s Set[T]) LargestGap(b, t T) (retval Span[T], found bool) {
+	var largest uint64
+	s.gaps(b, t, func(gap Span[T]) bool {
+		n := rangeLen(gap.Bot, gap.Top-1)
+		if n == 0 { // all 2^64 elements of a 64-bit type
+			retval, found = gap, true
+			return false
+		}
+		if n > largest {
+			retval, found, largest = gap, true, n
+		}
+		return true
+	})
+	return
+}
@@ -0,0 +1,115 @@
+package rangeset_test
+
+import (
+	"github.com/andrewwphillips/rangeset"
+	"math/rand/v2"
+	"testing"
+)
+
+type GapsElementType int16
+
+// gapsData provides table data for testing GapsSeq, FirstGap and LargestGap (using int16 elements)
+var gapsData = map[string]struct {
+	in      string
+	b, t    GapsElementType
+	gaps    string // the gaps as a set
+	largest rangeset.Span[GapsElementType]
+}{
+	"Empty":       {"{}", 1, 10, "{1:9}", rangeset.Span[GapsElementType]{1, 10}},
+	"EmptyWindow": {"{1:5}", 10, 10, "{}", rangeset.Span[GapsElementType]{}},
+	"NoGaps":      {"{1:20}", 5, 10, "{}", rangeset.Span[GapsElementType]{}},
+	"Two":         {"{1:5,9}", 0, 12, "{0,6:8,10:11}", rangeset.Span[GapsElementType]{6, 9}},
+	"InRange":     {"{1:5,9,20:30}", 3, 25, "{6:8,10:19}", rangeset.Span[GapsElementType]{10, 20}},
+	"ToEnd":       {"{1:5}", 3, -32768, "{6:E}", rangeset.Span[GapsElementType]{6, -32768}},
+	"FromStart":   {"{1:5}", -32768, 3, "{E:0}", rangeset.Span[GapsElementType]{-32768, 1}},
+	"EndInSet":    {"{1:5,100:E}", 0, -32768, "{0,6:99}", rangeset.Span[GapsElementType]{6, 100}},
+	"Universal":   {"{U}", -32768, -32768, "{}", rangeset.Span[GapsElementType]{}},
+	"Complement":  {"{}", -32768, -32768, "{U}", rangeset.Span[GapsElementType]{-32768, -32768}},
+}
+
+// TestGapsTable tests GapsSeq and LargestGap using the gapsData table
+func TestGapsTable(t *testing.T) {
+	for name, data := range gapsData {
+		s, _ := rangeset.NewFromString[GapsElementType](data.in)
+		var got rangeset.Set[GapsElementType]
+		for gap := range s.GapsSeq(data.b, data.t) {
+			got.AddRange(gap.Bot, gap.Top)
+		}
+		expected, _ := rangeset.NewFromString[GapsElementType](data.gaps)
+		Assertf(t, rangeset.Equal(got, expected), "GapsSeq: %12s: expected %v got %v", name, expected, got)
+
+		largest, ok := s.LargestGap(data.b, data.t)
+		Assertf(t, ok == (len(expected) > 0) && largest == data.largest, "LargestGap: %12s: expected %v got %v (%t)",
+			name, data.largest, largest, ok)
+	}
+}
+
+// TestFirstGap tests the FirstGap method
+func TestFirstGap(t *testing.T) {
+	s, _ := rangeset.NewFromString[uint8]("{1:5,8:10,20:30,40:E}")
+	for _, data := range []struct {
+		from     uint8
+		minLen   uint64
+		expected rangeset.Span[uint8]
+		ok       bool
+	}{
+		{0, 1, rangeset.Span[uint8]{0, 1}, true},
+		{0, 2, rangeset.Span[uint8]{6, 8}, true},
+		{0, 3, rangeset.Span[uint8]{11, 20}, true},
+		{7, 2, rangeset.Span[uint8]{11, 20}, true},
+		{15, 2, rangeset.Span[uint8]{15, 20}, true},
+		{25, 2, rangeset.Span[uint8]{31, 40}, true},
+		{25, 10, rangeset.Span[uint8]{}, false},
+		{50, 1, rangeset.Span[uint8]{}, false},
+	} {
+		got, ok := s.FirstGap(data.from, data.minLen)
+		Assertf(t, ok == data.ok && got == data.expected, "FirstGap: from %d (min %d) expected %v (%t) got %v (%t)",
+			data.from, data.minLen, data.expected, data.ok, got, ok)
+	}
+	got, ok := rangeset.Make[uint64]().FirstGap(0, 1<<63)
+	Assertf(t, ok && got == rangeset.Span[uint64]{0, 0}, "FirstGap: expected all of uint64 got %v (%t)", got, ok)
+}
+
+// testGapsBruteForce checks GapsSeq against a brute force calculation
+func testGapsBruteForce[T int8 | uint8](t *testing.T, name string) {
+	endMark := rangeset.Universal[T]()[0].Top
+	r := rand.New(rand.NewPCG(21, 22))
+	for range 500 {
+		s := randomSet[T](r)
+		b, top := T(r.IntN(256)), T(r.IntN(256))
+		if r.IntN(4) == 0 {
+			top = endMark
+		}
+		expected := bruteForce(func(e T) bool {
+			return !s.Contains(e) && e >= b && (e < top || top == endMark) && (top > b || top == endMark)
+		})
+		var got rangeset.Set[T]
+		for gap := range s.GapsSeq(b, top) {
+			Assertf(t, gap.Top > gap.Bot || gap.Top == endMark, "%s GapsSeq: %v [%d,%d) got empty gap %v", name, s, b, top, gap)
+			got = append(got, gap) // the gaps should be in order and not adjacent
+		}
+		Assertf(t, rangeset.Equal(got, expected), "%s GapsSeq: %v [%d,%d) expected %v got %v", name, s, b, top, expected, got)
+	}
+}
+
+// TestGapsBruteForce tests GapsSeq using 8-bit elements
+func TestGapsBruteForce(t *testing.T) {
+	testGapsBruteForce[int8](t, "int8")
+	testGapsBruteForce[uint8](t, "uint8")
+}
+
+// TestGapsAllocs checks that iterating the gaps does not allocate memory
+func TestGapsAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocations are not counted reliably with the race detector")
+	}
+	s, _ := rangeset.NewFromString[GapsElementType]("{1:10,20:30,40:50}")
+	allocs := testing.AllocsPerRun(100, func() {
+		for gap := range s.GapsSeq(0, 100) {
+			_ = gap
+		}
+		_, _ = s.FirstGap(0, 5)
+		_, _ = s.LargestGap(0, 100)
+	})
+	Assertf(t, allocs == 0, "GapsAllocs: expected no allocations got %v", allocs)
+}
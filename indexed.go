@@ -0,0 +1,264 @@
+package rangeset
+
+// indexed.go implements the Indexed type - a set that keeps track of the number of elements in
+// its ranges so that the methods in rank.go can be implemented more efficiently.
+
+import (
+	"math/big"
+	"math/rand/v2"
+	"sort"
+)
+
+// Indexed is a set that also stores the running total of the number of elements in its ranges.
+// This means that the number of elements is obtained in constant time and Rank and Select have
+// time complexity O(log r), but the index needs to be updated when the set is modified.
+// The zero value is an empty set ready to use.
+type Indexed[T Element] struct {
+	set Set[T]
+	cum []uint64 // cum[i] is the number of elements in the ranges before set[i]
+}
+
+// NewIndexed creates an Indexed set containing the elements of s (which is not modified)
+func NewIndexed[T Element](s Set[T]) *Indexed[T] {
+	ix := &Indexed[T]{set: s.Copy()}
+	ix.reindex(0)
+	return ix
+}
+
+// reindex recalculates the running totals from the range with index idx onwards
+func (ix *Indexed[T]) reindex(idx int) {
+	if len(ix.cum) == 0 {
+		ix.cum = append(ix.cum, 0)
+	}
+	idx = min(idx, len(ix.cum)-1, len(ix.set))
+	ix.cum = ix.cum[:idx+1]
+	for ; idx < len(ix.set); idx++ {
+		// Note that the total of all elements of a 64-bit type (2^64) wraps around to zero
+		ix.cum = append(ix.cum, ix.cum[idx]+rangeLen(ix.set[idx].Bot, ix.set[idx].Top-1))
+	}
+}
+
+// changed returns the index of the first range that may be affected by a change at element e
+func (ix *Indexed[T]) changed(e T) int {
+	return max(ix.set.bsearch(e)-1, 0)
+}
+
+// Set returns the underlying set.  It must not be modified (except through ix).
+func (ix *Indexed[T]) Set() Set[T] {
+	return ix.set
+}
+
+// Add inserts a single element into the set (see Set.Add)
+func (ix *Indexed[T]) Add(e T) bool {
+	idx := ix.changed(e)
+	if !ix.set.Add(e) {
+		return false
+	}
+	ix.reindex(idx)
+	return true
+}
+
+// AddRange inserts a range of elements into the set (see Set.AddRange)
+func (ix *Indexed[T]) AddRange(b, t T) {
+	idx := ix.changed(b)
+	ix.set.AddRange(b, t)
+	ix.reindex(idx)
+}
+
+// Delete removes a single element from the set (see Set.Delete)
+func (ix *Indexed[T]) Delete(e T) {
+	idx := ix.changed(e)
+	ix.set.Delete(e)
+	ix.reindex(idx)
+}
+
+// DeleteRange removes a range of elements from the set (see Set.DeleteRange)
+func (ix *Indexed[T]) DeleteRange(b, t T) {
+	idx := ix.changed(b)
+	ix.set.DeleteRange(b, t)
+	ix.reindex(idx)
+}
+
+// Contains tests whether the set contains an element
+func (ix *Indexed[T]) Contains(e T) bool {
+	return ix.set.Contains(e)
+}
+
+// Length returns the number of elements and number of ranges in the set, in constant time.
+// As for Set.Length, 0 elements is returned for the universal set of a 64-bit type.
+func (ix *Indexed[T]) Length() (length uint64, spans int) {
+	if len(ix.cum) == 0 {
+		return 0, 0
+	}
+	return ix.cum[len(ix.set)], len(ix.set)
+}
+
+// LengthBig returns the exact number of elements in the set (see Set.LengthBig)
+func (ix *Indexed[T]) LengthBig() *big.Int {
+	return ix.total().bigInt()
+}
+
+// Length128 returns the exact number of elements in the set (see Set.Length128)
+func (ix *Indexed[T]) Length128() (hi, lo uint64) {
+	c := ix.total()
+	return c.hi, c.lo
+}
+
+// Len returns the number of elements, or -1 if it's more than the largest int (see Set.Len)
+func (ix *Indexed[T]) Len() int {
+	length, spans := ix.Length()
+	if length > uint64(^uint(0)>>1) || length == 0 && spans > 0 {
+		return -1
+	}
+	return int(length)
+}
+
+// Rank returns the number of elements of the set that are less than e.
+// It has time complexity O(log r) where r is the number of ranges.
+func (ix *Indexed[T]) Rank(e T) uint64 {
+	idx := ix.set.bsearch(e)
+	if idx == 0 {
+		return 0
+	}
+	return ix.cum[idx-1] + rankIn(ix.set[idx-1], e)
+}
+
+// Select returns the element at position k (starting at zero) in the set or false if there are
+// not enough elements.  It has time complexity O(log r) where r is the number of ranges.
+func (ix *Indexed[T]) Select(k uint64) (T, bool) {
+	idx := ix.spanAt(k)
+	if idx < 0 {
+		return 0, false
+	}
+	return ix.set[idx].Bot + T(k-ix.cum[idx]), true
+}
+
+// spanAt returns the index of the range containing the element at position k, or -1 if
+// there are not enough elements
+func (ix *Indexed[T]) spanAt(k uint64) int {
+	// Find the last range that starts at or below position k
+	idx := sort.Search(len(ix.set), func(i int) bool { return ix.cum[i] > k }) - 1
+	if idx < 0 {
+		return -1
+	}
+	v := ix.set[idx]
+	if n := rangeLen(v.Bot, v.Top-1); n != 0 && k-ix.cum[idx] >= n {
+		return -1 // k is past the end of the last range
+	}
+	return idx
+}
+
+// CountRange returns the number of elements of the set in the range [b, t) (asymmetric bounds).
+// It has time complexity O(log r) where r is the number of ranges.
+func (ix *Indexed[T]) CountRange(b, t T) uint64 {
+	var endMark = minInt[T]() // indicates top/bottom of range of valid elements
+	if t <= b && t != endMark {
+		return 0
+	}
+	if t == endMark {
+		length, _ := ix.Length()
+		return length - ix.Rank(b)
+	}
+	return ix.Rank(t) - ix.Rank(b)
+}
+
+// Coverage returns the number of elements of the range [b, t) that are in the set and the
+// number of ranges of the set that overlap it (see Set.Coverage).  Unlike Set.Coverage it uses
+// the index so has time complexity O(log r) where r is the number of ranges.
+func (ix *Indexed[T]) Coverage(b, t T) (length uint64, spans int) {
+	var endMark = minInt[T]() // indicates top/bottom of range of valid elements
+	if t <= b && t != endMark {
+		return 0, 0
+	}
+	first := ix.set.bsearch(b) // index of the first range that starts after b
+	if first > 0 && b <= ix.set[first-1].Top-1 {
+		first-- // the previous range contains b
+	}
+	return ix.CountRange(b, t), ix.set.bsearch(t-1) - first // t-1 is the largest element if t is the end-mark
+}
+
+// CountRangeBig is the same as CountRange but returns the exact number of elements.
+// It has time complexity O(log r) where r is the number of ranges.
+func (ix *Indexed[T]) CountRangeBig(b, t T) *big.Int {
+	var endMark = minInt[T]() // indicates top/bottom of range of valid elements
+	if t <= b && t != endMark {
+		return new(big.Int)
+	}
+	if t == endMark {
+		return ix.total().sub(count{0, ix.Rank(b)}).bigInt() // can be 2^64
+	}
+	return new(big.Int).SetUint64(ix.Rank(t) - ix.Rank(b))
+}
+
+// Median returns the middle element (or lower of the middle two) or false if the set is empty.
+// It has time complexity O(log r) where r is the number of ranges.
+func (ix *Indexed[T]) Median() (T, bool) {
+	n := ix.total()
+	if n.isZero() {
+		return 0, false
+	}
+	return ix.Select(medianIndex(n))
+}
+
+// Percentile returns the element at percentile p (0 to 100) of the set (see Set.Percentile).
+// It has time complexity O(log r) where r is the number of ranges.
+func (ix *Indexed[T]) Percentile(p float64) (T, bool) {
+	k, ok := percentileIndex(ix.total(), p)
+	if !ok {
+		return 0, false
+	}
+	return ix.Select(k)
+}
+
+// RandomElement returns an element of the set chosen at random (see Set.RandomElement).
+// It has time complexity O(log r) where r is the number of ranges.
+func (ix *Indexed[T]) RandomElement(r *rand.Rand) (T, bool) {
+	n := ix.total()
+	if n.isZero() {
+		return 0, false
+	}
+	return ix.Select(randomPos(r, n))
+}
+
+// RandomSpan returns a range of the set chosen at random (see Set.RandomSpan).
+// It has time complexity O(log r) where r is the number of ranges.
+func (ix *Indexed[T]) RandomSpan(r *rand.Rand) (Span[T], bool) {
+	n := ix.total()
+	if n.isZero() {
+		return Span[T]{}, false
+	}
+	return ix.set[ix.spanAt(randomPos(r, n))], true
+}
+
+// Sample returns k elements of the set chosen at random (see Set.Sample).
+// It has time complexity O(k log r) where r is the number of ranges, and uses O(k) memory.
+func (ix *Indexed[T]) Sample(r *rand.Rand, k int, replace bool) []T {
+	n := ix.total()
+	if n.isZero() || k <= 0 {
+		return nil // nothing to choose (and a negative k can't be used for the capacity below)
+	}
+	if replace {
+		retval := make([]T, 0, k)
+		for range k {
+			e, _ := ix.Select(randomPos(r, n))
+			retval = append(retval, e)
+		}
+		return retval
+	}
+	positions := sample(r, n, k)
+	retval := make([]T, 0, len(positions))
+	for _, pos := range positions {
+		e, _ := ix.Select(pos)
+		retval = append(retval, e)
+	}
+	return retval
+}
+
+// total returns the exact number of elements
+func (ix *Indexed[T]) total() count {
+	length, spans := ix.Length()
+	if length == 0 && spans > 0 {
+		return count{1, 0} // 2^64
+	}
+	return count{0, length}
+}
@@ -0,0 +1,66 @@
+package rangeset_test
+
+import (
+	"github.com/andrewwphillips/rangeset"
+	"math/rand/v2"
+	"testing"
+)
+
+// testIndexedUpdate makes random changes to an Indexed set (and a normal set) checking they stay the same
+func testIndexedUpdate[T int8 | uint8](t *testing.T, name string) {
+	endMark := rangeset.Universal[T]()[0].Top
+	r := rand.New(rand.NewPCG(15, 16))
+	for range 200 {
+		var s rangeset.Set[T]
+		var ix rangeset.Indexed[T] // zero value should be usable
+		for range 20 {
+			b, top := T(r.IntN(256)), T(r.IntN(256))
+			if r.IntN(8) == 0 {
+				top = endMark
+			}
+			switch r.IntN(4) {
+			case 0:
+				Assertf(t, s.Add(b) == ix.Add(b), "%s IndexedUpdate: Add(%d) returned different results", name, b)
+			case 1:
+				s.AddRange(b, top)
+				ix.AddRange(b, top)
+			case 2:
+				s.Delete(b)
+				ix.Delete(b)
+			case 3:
+				s.DeleteRange(b, top)
+				ix.DeleteRange(b, top)
+			}
+			Assertf(t, rangeset.Equal(s, ix.Set()), "%s IndexedUpdate: expected %v got %v", name, s, ix.Set())
+
+			length, spans := s.Length()
+			gotLength, gotSpans := ix.Length()
+			Assertf(t, length == gotLength && spans == gotSpans, "%s IndexedUpdate: %v: expected length %d,%d got %d,%d",
+				name, s, length, spans, gotLength, gotSpans)
+			Assertf(t, s.Len() == ix.Len(), "%s IndexedUpdate: %v: expected Len %d got %d", name, s, s.Len(), ix.Len())
+			e := T(r.IntN(256))
+			Assertf(t, s.Rank(e) == ix.Rank(e), "%s IndexedUpdate: %v: expected Rank(%d) of %d got %d",
+				name, s, e, s.Rank(e), ix.Rank(e))
+			Assertf(t, s.Contains(e) == ix.Contains(e), "%s IndexedUpdate: %v: Contains(%d) differs", name, s, e)
+			length, spans = s.Coverage(b, top)
+			gotLength, gotSpans = ix.Coverage(b, top)
+			Assertf(t, length == gotLength && spans == gotSpans, "%s IndexedUpdate: %v: expected Coverage(%d, %d) %d,%d got %d,%d",
+				name, s, b, top, length, spans, gotLength, gotSpans)
+		}
+	}
+}
+
+// TestIndexedUpdate checks that the index of an Indexed set is kept up to date as the set is modified
+func TestIndexedUpdate(t *testing.T) {
+	testIndexedUpdate[int8](t, "int8")
+	testIndexedUpdate[uint8](t, "uint8")
+}
+
+// TestNewIndexed checks that NewIndexed copies the set
+func TestNewIndexed(t *testing.T) {
+	s := rangeset.NewFromRange(1, 10)
+	ix := rangeset.NewIndexed(s)
+	ix.Add(20)
+	Assertf(t, s.Len() == 9, "NewIndexed: expected original set to have 9 elements got %d", s.Len())
+	Assertf(t, ix.Len() == 10, "NewIndexed: expected indexed set to have 10 elements got %d", ix.Len())
+}
@@ -4,7 +4,9 @@ package rangeset
 // string conversions and getting the min/max allowed values for an element type
 
 import (
+	"errors"
 	"math"
+	"math/bits"
 	"strconv"
 )
 
@@ -15,15 +17,21 @@ func isUnsigned[T Element]() bool {
 }
 
 // parseInt converts a decimal string to the type of it's type parameter
-// The return value is undefined if the value overflows the size of the type parameter
-// TODO: pass bitSize to ParseInt/Uint depending on type instead of just using 64 (to get error on overflow)
+// It returns ErrOverflow if the value does not fit in the type parameter (even if it does not
+// fit in 64 bits, when strconv returns ErrRange).
 func parseInt[T Element](s string) (T, error) {
 	if isUnsigned[T]() {
 		// parse unsigned int
 		v, err := strconv.ParseUint(s, 10, 64)
+		if errors.Is(err, strconv.ErrRange) || err == nil && uint64(T(v)) != v {
+			err = ErrOverflow
+		}
 		return T(v), err
 	}
 	v, err := strconv.ParseInt(s, 10, 64)
+	if errors.Is(err, strconv.ErrRange) || err == nil && int64(T(v)) != v {
+		err = ErrOverflow
+	}
 	return T(v), err
 }
 
@@ -67,3 +75,57 @@ func minInt[T Element]() T {
 func maxInt[T Element]() T {
 	return minInt[T]() - 1 // This works for unsigned and signed (2's complement) ints
 }
+
+// addSat returns e + k, or the largest integer (maxInt) if the result overflows
+func addSat[T Element](e T, k uint64) T {
+	if distance(e, maxInt[T]()) < k {
+		return maxInt[T]()
+	}
+	return e + T(k)
+}
+
+// subSat returns e - k, or the smallest integer (minInt) if the result overflows
+func subSat[T Element](e T, k uint64) T {
+	if distance(minInt[T](), e) < k {
+		return minInt[T]()
+	}
+	return e - T(k)
+}
+
+// int128 is a signed 128-bit integer, which is big enough to hold the result of adding (or
+// subtracting) an int64 to any element, so that overflow of the element type can be detected
+type int128 struct {
+	hi int64
+	lo uint64
+}
+
+// toInt128 converts an element (or an int64) to an int128
+func toInt128[T Element](e T) int128 {
+	if isUnsigned[T]() {
+		return int128{0, uint64(e)}
+	}
+	return int128{int64(e) >> 63, uint64(e)} // hi is all ones if e is negative
+}
+
+func (a int128) add(b int128) int128 {
+	lo, carry := bits.Add64(a.lo, b.lo, 0)
+	return int128{a.hi + b.hi + int64(carry), lo}
+}
+
+func (a int128) neg() int128 {
+	lo, borrow := bits.Sub64(0, a.lo, 0)
+	return int128{-a.hi - int64(borrow), lo}
+}
+
+func (a int128) less(b int128) bool {
+	return a.hi < b.hi || a.hi == b.hi && a.lo < b.lo
+}
+
+// floorDiv returns e/k rounded down (rather than towards zero), where k is positive
+func floorDiv[T Element](e, k T) T {
+	q := e / k
+	if e%k != 0 && e < 0 {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,371 @@
+package rangeset
+
+// merge.go implements internal functions that walk the (ordered) spans of two sets
+// at the same time, so that set operations take O(r1+r2) time, where r1 and r2 are
+// the number of ranges in each set.
+//
+// Note that these work with the *last* element of a span (ie Top-1) rather than Top.
+// When Top is the end-mark then Top-1 wraps around to the largest element, so spans
+// that extend to the top of the element type do not need special handling.
+
+import (
+	"slices"
+)
+
+// appendSpan adds the elements [b, last] (inclusive) to the end of s, joining them onto
+// the last span of s if they are adjacent.  The elements must all be above those of s.
+func appendSpan[T Element](s Set[T], b, last T) Set[T] {
+	if n := len(s); n > 0 && s[n-1].Top == b {
+		s[n-1].Top = last + 1
+		return s
+	}
+	return append(s, Span[T]{b, last + 1}) // last+1 wraps to the end-mark if last is maxInt
+}
+
+// unionSpans calls yield on the ranges [b, last] of the union of s1 and s2, in order.
+// Iteration stops early if yield returns false.
+func unionSpans[T Element](s1, s2 Set[T], yield func(b, last T) bool) {
+	maxElt := maxInt[T]()
+	var b, last T
+	started := false
+	for i, j := 0, 0; i < len(s1) || j < len(s2); {
+		// Take whichever span starts first
+		var v Span[T]
+		if j == len(s2) || i < len(s1) && s1[i].Bot < s2[j].Bot {
+			v = s1[i]
+			i++
+		} else {
+			v = s2[j]
+			j++
+		}
+		if started && (last == maxElt || v.Bot <= last+1) {
+			// v overlaps or is adjacent to the current range so just extend it
+			if v.Top-1 > last {
+				last = v.Top - 1
+			}
+			continue
+		}
+		if started && !yield(b, last) {
+			return
+		}
+		b, last, started = v.Bot, v.Top-1, true
+	}
+	if started {
+		yield(b, last)
+	}
+}
+
+// intersectSpans calls yield on the ranges [b, last] of the intersection of s1 and s2, in
+// order.  Iteration stops early if yield returns false.
+func intersectSpans[T Element](s1, s2 Set[T], yield func(b, last T) bool) {
+	for i, j := 0, 0; i < len(s1) && j < len(s2); {
+		last1, last2 := s1[i].Top-1, s2[j].Top-1
+		if b, last := max(s1[i].Bot, s2[j].Bot), min(last1, last2); b <= last && !yield(b, last) {
+			return
+		}
+		// Move past whichever span(s) finish first
+		if last1 <= last2 {
+			i++
+		}
+		if last2 <= last1 {
+			j++
+		}
+	}
+}
+
+// differenceSpans calls yield on the ranges [b, last] of the elements of s1 that are not
+// in s2, in order.  Iteration stops early if yield returns false.
+func differenceSpans[T Element](s1, s2 Set[T], yield func(b, last T) bool) {
+	j := 0
+outer:
+	for _, v := range s1 {
+		b, last := v.Bot, v.Top-1
+		for j < len(s2) && s2[j].Top-1 < b {
+			j++ // skip spans of s2 that are entirely below v
+		}
+		// Remove any spans of s2 that overlap v
+		for ; j < len(s2) && s2[j].Bot <= last; j++ {
+			if s2[j].Bot > b && !yield(b, s2[j].Bot-1) {
+				return
+			}
+			if s2[j].Top-1 >= last {
+				continue outer // the rest of v is removed (s2[j] may also overlap the next span)
+			}
+			b = s2[j].Top
+		}
+		if !yield(b, last) {
+			return
+		}
+	}
+}
+
+// symmetricDifferenceSpans calls yield on the ranges [b, last] of the elements that are in
+// either s1 or s2 but not both, in order.  Iteration stops early if yield returns false.
+func symmetricDifferenceSpans[T Element](s1, s2 Set[T], yield func(b, last T) bool) {
+	// Ranges are held back (in pb, pl) until we know they are not adjacent to the next one
+	var pb, pl T
+	pending := false
+	emit := func(b, last T) bool {
+		if pending && b == pl+1 {
+			pl = last
+			return true
+		}
+		if pending && !yield(pb, pl) {
+			return false
+		}
+		pb, pl, pending = b, last, true
+		return true
+	}
+
+	// b1, l1 and b2, l2 are the bottom and last elements of what remains of s1[i] and s2[j]
+	i, j := -1, -1
+	var b1, l1, b2, l2 T
+	next1 := func() bool {
+		if i++; i < len(s1) {
+			b1, l1 = s1[i].Bot, s1[i].Top-1
+			return true
+		}
+		return false
+	}
+	next2 := func() bool {
+		if j++; j < len(s2) {
+			b2, l2 = s2[j].Bot, s2[j].Top-1
+			return true
+		}
+		return false
+	}
+
+	ok := true
+	more1, more2 := next1(), next2()
+	for more1 && more2 {
+		switch {
+		case b1 < b2:
+			if l1 < b2 {
+				ok = emit(b1, l1)
+				more1 = next1()
+			} else {
+				ok = emit(b1, b2-1)
+				b1 = b2
+			}
+		case b2 < b1:
+			if l2 < b1 {
+				ok = emit(b2, l2)
+				more2 = next2()
+			} else {
+				ok = emit(b2, b1-1)
+				b2 = b1
+			}
+		// Both start at the same element so skip the common part
+		case l1 < l2:
+			b2 = l1 + 1
+			more1 = next1()
+		case l2 < l1:
+			b1 = l2 + 1
+			more2 = next2()
+		default:
+			more1, more2 = next1(), next2()
+		}
+		if !ok {
+			return
+		}
+	}
+	for ; more1; more1 = next1() {
+		if !emit(b1, l1) {
+			return
+		}
+	}
+	for ; more2; more2 = next2() {
+		if !emit(b2, l2) {
+			return
+		}
+	}
+	if pending {
+		yield(pb, pl)
+	}
+}
+
+// unionOf returns a new set that is the union of s1 and s2
+func unionOf[T Element](s1, s2 Set[T]) Set[T] {
+	retval := make(Set[T], 0, len(s1)+len(s2))
+	unionSpans(s1, s2, func(b, last T) bool {
+		retval = appendSpan(retval, b, last)
+		return true
+	})
+	return retval
+}
+
+// intersectionOf returns a new set that is the intersection of s1 and s2
+func intersectionOf[T Element](s1, s2 Set[T]) Set[T] {
+	retval := make(Set[T], 0, max(len(s1), len(s2)))
+	intersectSpans(s1, s2, func(b, last T) bool {
+		retval = appendSpan(retval, b, last)
+		return true
+	})
+	return retval
+}
+
+// differenceOf returns a new set of the elements of s1 that are not in s2
+func differenceOf[T Element](s1, s2 Set[T]) Set[T] {
+	retval := make(Set[T], 0, len(s1))
+	differenceSpans(s1, s2, func(b, last T) bool {
+		retval = appendSpan(retval, b, last)
+		return true
+	})
+	return retval
+}
+
+// symmetricDifferenceOf returns a new set of the elements that are in s1 or s2 but not both
+func symmetricDifferenceOf[T Element](s1, s2 Set[T]) Set[T] {
+	retval := make(Set[T], 0, len(s1)+len(s2))
+	symmetricDifferenceSpans(s1, s2, func(b, last T) bool {
+		retval = appendSpan(retval, b, last)
+		return true
+	})
+	return retval
+}
+
+// symmetricDifferenceK returns a new set of the elements that are in an odd number of the sets.
+// Since each span boundary toggles whether an element is in the result, the result's boundaries
+// are those that occur an odd number of times.  It has time complexity O(r log r).
+func symmetricDifferenceK[T Element](sets []Set[T]) Set[T] {
+	var endMark = minInt[T]()
+	var bounds []T
+	for _, s := range sets {
+		for _, v := range s {
+			bounds = append(bounds, v.Bot)
+			if v.Top != endMark {
+				bounds = append(bounds, v.Top)
+			}
+		}
+	}
+	slices.Sort(bounds)
+
+	retval := Set[T]{}
+	in := false // is the current element in the result?
+	for idx := 0; idx < len(bounds); {
+		// Count how many times this boundary occurs
+		b, count := bounds[idx], 0
+		for ; idx < len(bounds) && bounds[idx] == b; idx++ {
+			count++
+		}
+		if count%2 == 0 {
+			continue
+		}
+		if in {
+			retval[len(retval)-1].Top = b
+		} else {
+			retval = append(retval, Span[T]{b, endMark}) // Top is set at the next boundary (if any)
+		}
+		in = !in
+	}
+	return retval
+}
+
+// spanHeap is a min-heap of sets (or what remains of them) used for k-way merges.  Each
+// set is ordered by its first span - using either the bottom or last element of the span.
+type spanHeap[T Element] struct {
+	sets   []Set[T]
+	byLast bool // order sets by the last element (rather than the bottom) of their first span
+}
+
+// newSpanHeap creates a heap of the non-empty sets of its parameter (which is not modified)
+func newSpanHeap[T Element](sets []Set[T], byLast bool) *spanHeap[T] {
+	h := &spanHeap[T]{sets: make([]Set[T], 0, len(sets)), byLast: byLast}
+	for _, s := range sets {
+		if len(s) > 0 {
+			h.sets = append(h.sets, s)
+		}
+	}
+	for i := len(h.sets)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+	return h
+}
+
+func (h *spanHeap[T]) less(i, j int) bool {
+	if h.byLast {
+		return h.sets[i][0].Top-1 < h.sets[j][0].Top-1
+	}
+	return h.sets[i][0].Bot < h.sets[j][0].Bot
+}
+
+// down moves the set at index i down the heap till it is not greater than its children
+func (h *spanHeap[T]) down(i int) {
+	for n := len(h.sets); ; {
+		child := 2*i + 1
+		if child >= n {
+			return
+		}
+		if child+1 < n && h.less(child+1, child) {
+			child++
+		}
+		if !h.less(child, i) {
+			return
+		}
+		h.sets[i], h.sets[child] = h.sets[child], h.sets[i]
+		i = child
+	}
+}
+
+// next moves the set at the top of the heap on to its next span, removing the set from
+// the heap if it has no more spans.
+func (h *spanHeap[T]) next() {
+	if h.sets[0] = h.sets[0][1:]; len(h.sets[0]) == 0 {
+		n := len(h.sets) - 1
+		h.sets[0] = h.sets[n]
+		h.sets = h.sets[:n]
+	}
+	h.down(0)
+}
+
+// unionK returns a new set that is the union of all the sets by doing a k-way merge
+// It has time complexity O(r log k) where r is the total number of ranges in all the sets.
+func unionK[T Element](sets []Set[T]) Set[T] {
+	h := newSpanHeap(sets, false)
+	maxElt := maxInt[T]()
+	retval := make(Set[T], 0, len(sets))
+	for len(h.sets) > 0 {
+		v := h.sets[0][0] // span that starts first
+		h.next()
+		if n := len(retval); n > 0 {
+			if last := retval[n-1].Top - 1; last == maxElt || v.Bot <= last+1 {
+				// Overlaps or is adjacent to the last span so just extend it
+				if v.Top-1 > last {
+					retval[n-1].Top = v.Top
+				}
+				continue
+			}
+		}
+		retval = append(retval, v)
+	}
+	return retval
+}
+
+// intersectK returns a new set that is the intersection of all the sets by doing a k-way
+// merge.  It has time complexity O(r log k) where r is the total number of ranges.
+func intersectK[T Element](sets []Set[T]) Set[T] {
+	retval := Set[T]{}
+	if len(sets) == 0 {
+		return retval
+	}
+	// We keep track of the highest bottom of the current spans of all the sets, and pull
+	// from the heap the span that finishes first - any overlap of these is in the result.
+	b := minInt[T]()
+	for _, s := range sets {
+		if len(s) == 0 {
+			return retval // intersection with the empty set is empty
+		}
+		b = max(b, s[0].Bot)
+	}
+	h := newSpanHeap(sets, true)
+	for {
+		if last := h.sets[0][0].Top - 1; b <= last {
+			retval = appendSpan(retval, b, last)
+		}
+		if h.sets[0] = h.sets[0][1:]; len(h.sets[0]) == 0 {
+			return retval // no more elements in one of the sets
+		}
+		b = max(b, h.sets[0][0].Bot)
+		h.down(0)
+	}
+}
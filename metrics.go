@@ -0,0 +1,219 @@
+package rangeset
+
+// metrics.go implements measures of the similarity (or distance) between two sets
+
+import (
+	"math/big"
+)
+
+// Metrics holds measures of how similar two sets are - see the Similarity function
+type Metrics struct {
+	len1, len2, intersection, union count // exact number of elements
+	hausdorff                       uint64
+	hausdorffOK                     bool // false if the Hausdorff distance is not defined
+}
+
+// Similarity compares two sets, returning Metrics from which measures of their similarity (such
+// as the Jaccard index) can be obtained.  Everything is calculated in a single pass over the
+// ranges of both sets, so it has time complexity O(r1+r2) and does not allocate memory.
+func Similarity[T Element](s1, s2 Set[T]) (m Metrics) {
+	var h1, h2 uint64            // greatest distance of an element of s1 from s2 and vice versa
+	var last1, last2 T           // last element (so far) of s1 and s2 ...
+	seen1, seen2 := false, false // ... if one has been seen
+
+	// b1, l1 and b2, l2 are the bottom and last elements of what remains of s1[i] and s2[j]
+	i, j := -1, -1
+	var b1, l1, b2, l2 T
+	next1 := func() bool {
+		if i++; i < len(s1) {
+			b1, l1 = s1[i].Bot, s1[i].Top-1
+			return true
+		}
+		return false
+	}
+	next2 := func() bool {
+		if j++; j < len(s2) {
+			b2, l2 = s2[j].Bot, s2[j].Top-1
+			return true
+		}
+		return false
+	}
+
+	for more1, more2 := next1(), next2(); more1 || more2; {
+		switch {
+		case more1 && (!more2 || b1 < b2):
+			// Elements only in s1 (up to the start of the current s2 span)
+			last := l1
+			if more2 && l1 >= b2 {
+				last = b2 - 1
+			}
+			n := rangeLen(b1, last)
+			m.len1.addLen(n)
+			m.union.addLen(n)
+			h1 = max(h1, furthest(b1, last, last2, seen2, b2, more2))
+			last1, seen1 = last, true
+			if last == l1 {
+				more1 = next1()
+			} else {
+				b1 = b2
+			}
+
+		case more2 && (!more1 || b2 < b1):
+			// Elements only in s2
+			last := l2
+			if more1 && l2 >= b1 {
+				last = b1 - 1
+			}
+			n := rangeLen(b2, last)
+			m.len2.addLen(n)
+			m.union.addLen(n)
+			h2 = max(h2, furthest(b2, last, last1, seen1, b1, more1))
+			last2, seen2 = last, true
+			if last == l2 {
+				more2 = next2()
+			} else {
+				b2 = b1
+			}
+
+		default:
+			// Elements in both sets
+			last := min(l1, l2)
+			n := rangeLen(b1, last)
+			m.len1.addLen(n)
+			m.len2.addLen(n)
+			m.intersection.addLen(n)
+			m.union.addLen(n)
+			last1, last2, seen1, seen2 = last, last, true, true
+			switch {
+			case l1 < l2:
+				b2 = l1 + 1
+				more1 = next1()
+			case l2 < l1:
+				b1 = l2 + 1
+				more2 = next2()
+			default:
+				more1, more2 = next1(), next2()
+			}
+		}
+	}
+	m.hausdorff = max(h1, h2)
+	m.hausdorffOK = (len(s1) == 0) == (len(s2) == 0)
+	return
+}
+
+// furthest returns the greatest distance of an element in the range [b, last] from the nearest
+// element of another set, where prev and next are the elements of the other set immediately
+// below and above the range (if hasPrev and hasNext respectively).
+func furthest[T Element](b, last, prev T, hasPrev bool, next T, hasNext bool) uint64 {
+	switch {
+	case hasPrev && hasNext:
+		// The furthest element (from prev and next) is halfway between them, if in the range
+		var retval uint64
+		mid := prev + T(distance(prev, next)/2)
+		for _, e := range [2]T{mid, mid + 1} {
+			e = min(max(e, b), last)
+			retval = max(retval, min(distance(prev, e), distance(e, next)))
+		}
+		return retval
+	case hasPrev:
+		return distance(prev, last)
+	case hasNext:
+		return distance(b, next)
+	}
+	return 0
+}
+
+// distance returns how far apart two elements are, where a <= b
+func distance[T Element](a, b T) uint64 {
+	return uint64(b) - uint64(a) // sign-extension of signed values does not affect the result
+}
+
+// Jaccard returns the Jaccard index of the sets - the number of elements in their intersection
+// divided by the number in their union.  This is 1 for identical sets (including two empty
+// sets) and 0 for sets with no elements in common.
+func (m Metrics) Jaccard() float64 {
+	if m.union.isZero() {
+		return 1
+	}
+	return m.intersection.float64() / m.union.float64()
+}
+
+// JaccardRat is the same as Jaccard but returns the exact value as a fraction
+func (m Metrics) JaccardRat() *big.Rat {
+	if m.union.isZero() {
+		return big.NewRat(1, 1)
+	}
+	return new(big.Rat).SetFrac(m.intersection.bigInt(), m.union.bigInt())
+}
+
+// Dice returns the Dice (or Sørensen–Dice) coefficient of the sets - twice the number of elements
+// in their intersection divided by the total number of elements in both sets.  Like the Jaccard
+// index it is 1 for identical sets and 0 for sets with no elements in common.
+func (m Metrics) Dice() float64 {
+	if m.union.isZero() {
+		return 1
+	}
+	return 2 * m.intersection.float64() / (m.len1.float64() + m.len2.float64())
+}
+
+// DiceRat is the same as Dice but returns the exact value as a fraction
+func (m Metrics) DiceRat() *big.Rat {
+	if m.union.isZero() {
+		return big.NewRat(1, 1)
+	}
+	total := m.len1
+	total.add(m.len2)
+	x := m.intersection.bigInt()
+	return new(big.Rat).SetFrac(x.Lsh(x, 1), total.bigInt())
+}
+
+// Overlap returns the overlap (or Szymkiewicz–Simpson) coefficient of the sets - the number of
+// elements in their intersection divided by the number of elements in the smaller set.  This is
+// 1 if one set is a subset of the other, except that if only one set is empty it returns 0.
+func (m Metrics) Overlap() float64 {
+	if m.union.isZero() {
+		return 1
+	}
+	if m.len1.isZero() || m.len2.isZero() {
+		return 0
+	}
+	return m.intersection.float64() / m.smaller().float64()
+}
+
+// OverlapRat is the same as Overlap but returns the exact value as a fraction
+func (m Metrics) OverlapRat() *big.Rat {
+	if m.union.isZero() {
+		return big.NewRat(1, 1)
+	}
+	if m.len1.isZero() || m.len2.isZero() {
+		return new(big.Rat)
+	}
+	return new(big.Rat).SetFrac(m.intersection.bigInt(), m.smaller().bigInt())
+}
+
+// smaller returns the number of elements in the smaller of the two sets
+func (m Metrics) smaller() count {
+	if m.len2.less(m.len1) {
+		return m.len2
+	}
+	return m.len1
+}
+
+// Hamming returns the Hamming distance between the sets - the number of elements in one or the
+// other but not both (ie the size of the symmetric difference).  Like the Length() method it
+// returns 0 if the result is all the elements of a 64-bit type.
+func (m Metrics) Hamming() uint64 {
+	return m.union.sub(m.intersection).lo
+}
+
+// HammingBig is the same as Hamming but returns the exact value
+func (m Metrics) HammingBig() *big.Int {
+	return m.union.sub(m.intersection).bigInt()
+}
+
+// Hausdorff returns the Hausdorff distance between the sets - the greatest distance of an
+// element of one set from the nearest element of the other set.  If only one of the sets
+// is empty the distance is not defined, in which case it returns false.
+func (m Metrics) Hausdorff() (uint64, bool) {
+	return m.hausdorff, m.hausdorffOK
+}
@@ -0,0 +1,142 @@
+package rangeset_test
+
+import (
+	"github.com/andrewwphillips/rangeset"
+	"math/big"
+	"math/rand/v2"
+	"testing"
+)
+
+type MetricsElementType int
+
+// metricsData is for table-driven tests of the Similarity function
+var metricsData = map[string]struct {
+	s1, s2                 string
+	jaccard, dice, overlap string // fractions as accepted by big.Rat SetString
+	hamming, hausdorff     uint64
+	hausdorffOK            bool
+}{
+	"BothEmpty":  {"{}", "{}", "1", "1", "1", 0, 0, true},
+	"OneEmpty":   {"{1:10}", "{}", "0", "0", "0", 10, 0, false},
+	"Same":       {"{1:10}", "{1:10}", "1", "1", "1", 0, 0, true},
+	"Disjoint":   {"{1:10}", "{21:30}", "0", "0", "0", 20, 20, true},
+	"Half":       {"{1:10}", "{6:15}", "1/3", "1/2", "1/2", 10, 5, true},
+	"Subset":     {"{1:10}", "{3:4}", "1/5", "1/3", "1", 8, 6, true},
+	"Gap":        {"{1:20}", "{1,20}", "1/10", "2/11", "1", 18, 9, true},
+	"GapOdd":     {"{1:21}", "{1,21}", "2/21", "4/23", "1", 19, 10, true},
+	"Interleave": {"{1,3,5}", "{2,4,6}", "0", "0", "0", 6, 1, true},
+}
+
+// TestMetricsTable tests the Similarity function using the metricsData table
+func TestMetricsTable(t *testing.T) {
+	for name, data := range metricsData {
+		s1, _ := rangeset.NewFromString[MetricsElementType](data.s1)
+		s2, _ := rangeset.NewFromString[MetricsElementType](data.s2)
+		for _, m := range []rangeset.Metrics{rangeset.Similarity(s1, s2), rangeset.Similarity(s2, s1)} {
+			expected, _ := new(big.Rat).SetString(data.jaccard)
+			Assertf(t, m.JaccardRat().Cmp(expected) == 0, "Metrics: %12s: expected Jaccard %v got %v", name, expected, m.JaccardRat())
+			f, _ := expected.Float64()
+			Assertf(t, m.Jaccard() == f, "Metrics: %12s: expected Jaccard %v got %v", name, f, m.Jaccard())
+
+			expected, _ = new(big.Rat).SetString(data.dice)
+			Assertf(t, m.DiceRat().Cmp(expected) == 0, "Metrics: %12s: expected Dice %v got %v", name, expected, m.DiceRat())
+			f, _ = expected.Float64()
+			Assertf(t, m.Dice() == f, "Metrics: %12s: expected Dice %v got %v", name, f, m.Dice())
+
+			expected, _ = new(big.Rat).SetString(data.overlap)
+			Assertf(t, m.OverlapRat().Cmp(expected) == 0, "Metrics: %12s: expected Overlap %v got %v", name, expected, m.OverlapRat())
+			f, _ = expected.Float64()
+			Assertf(t, m.Overlap() == f, "Metrics: %12s: expected Overlap %v got %v", name, f, m.Overlap())
+
+			Assertf(t, m.Hamming() == data.hamming, "Metrics: %12s: expected Hamming %d got %d", name, data.hamming, m.Hamming())
+			h, ok := m.Hausdorff()
+			Assertf(t, h == data.hausdorff && ok == data.hausdorffOK, "Metrics: %12s: expected Hausdorff %d (%t) got %d (%t)",
+				name, data.hausdorff, data.hausdorffOK, h, ok)
+		}
+	}
+}
+
+// hausdorff finds the Hausdorff distance between 2 sets of elements by brute force
+func hausdorff[T int8 | uint8](e1, e2 []T) int {
+	directed := func(from, to []T) int {
+		retval := 0
+		for _, a := range from {
+			nearest := 1000
+			for _, b := range to {
+				nearest = min(nearest, max(int(a)-int(b), int(b)-int(a)))
+			}
+			retval = max(retval, nearest)
+		}
+		return retval
+	}
+	return max(directed(e1, e2), directed(e2, e1))
+}
+
+// testMetricsBruteForce compares the Metrics returned by Similarity with brute force calculations
+func testMetricsBruteForce[T int8 | uint8](t *testing.T, name string) {
+	r := rand.New(rand.NewPCG(11, 12))
+	for range 1000 {
+		s1, s2 := randomSet[T](r), randomSet[T](r)
+		e1, e2 := elements(s1), elements(s2)
+		inter, union := len(elements(rangeset.Intersect(s1, s2))), len(elements(rangeset.Union(s1, s2)))
+		m := rangeset.Similarity(s1, s2)
+
+		if union > 0 {
+			expected := big.NewRat(int64(inter), int64(union))
+			Assertf(t, m.JaccardRat().Cmp(expected) == 0, "%s Metrics: %v, %v: expected Jaccard %v got %v",
+				name, s1, s2, expected, m.JaccardRat())
+			expected = big.NewRat(int64(2*inter), int64(len(e1)+len(e2)))
+			Assertf(t, m.DiceRat().Cmp(expected) == 0, "%s Metrics: %v, %v: expected Dice %v got %v",
+				name, s1, s2, expected, m.DiceRat())
+		}
+		if len(e1) > 0 && len(e2) > 0 {
+			expected := big.NewRat(int64(inter), int64(min(len(e1), len(e2))))
+			Assertf(t, m.OverlapRat().Cmp(expected) == 0, "%s Metrics: %v, %v: expected Overlap %v got %v",
+				name, s1, s2, expected, m.OverlapRat())
+			h, ok := m.Hausdorff()
+			Assertf(t, ok && int(h) == hausdorff(e1, e2), "%s Metrics: %v, %v: expected Hausdorff %d got %d (%t)",
+				name, s1, s2, hausdorff(e1, e2), h, ok)
+		}
+		Assertf(t, int(m.Hamming()) == union-inter, "%s Metrics: %v, %v: expected Hamming %d got %d",
+			name, s1, s2, union-inter, m.Hamming())
+	}
+}
+
+// TestMetricsBruteForce tests Similarity against brute force calculations (using 8-bit elements)
+func TestMetricsBruteForce(t *testing.T) {
+	testMetricsBruteForce[int8](t, "int8")
+	testMetricsBruteForce[uint8](t, "uint8")
+}
+
+// TestMetrics64 tests Similarity with 64-bit sets, where the number of elements can overflow uint64
+func TestMetrics64(t *testing.T) {
+	u := rangeset.Universal[uint64]()
+	m := rangeset.Similarity(u, u)
+	Assertf(t, m.Jaccard() == 1, "Metrics64: expected Jaccard of 1 for U and U got %v", m.Jaccard())
+	Assertf(t, m.Hamming() == 0, "Metrics64: expected Hamming of 0 for U and U got %v", m.Hamming())
+
+	m = rangeset.Similarity(u, rangeset.Make[uint64](0, 1))
+	expected := new(big.Rat).SetFrac(big.NewInt(2), new(big.Int).Lsh(big.NewInt(1), 64))
+	Assertf(t, m.JaccardRat().Cmp(expected) == 0, "Metrics64: expected Jaccard %v got %v", expected, m.JaccardRat())
+	Assertf(t, m.Overlap() == 1, "Metrics64: expected Overlap of 1 got %v", m.Overlap())
+	Assertf(t, m.Hamming() == ^uint64(0)-1, "Metrics64: expected Hamming %d got %d", ^uint64(0)-1, m.Hamming())
+	h, ok := m.Hausdorff()
+	Assertf(t, ok && h == ^uint64(0)-1, "Metrics64: expected Hausdorff %d got %d (%t)", ^uint64(0)-1, h, ok)
+
+	m = rangeset.Similarity(rangeset.Universal[int64](), rangeset.Make[int64](0))
+	h, ok = m.Hausdorff()
+	Assertf(t, ok && h == 1<<63, "Metrics64: expected Hausdorff %d got %d (%t)", uint64(1<<63), h, ok)
+}
+
+// TestMetricsAllocs checks that Similarity does not allocate memory
+func TestMetricsAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocations are not counted reliably with the race detector")
+	}
+	s1, _ := rangeset.NewFromString[MetricsElementType]("{1:10,20:30,40:50}")
+	s2, _ := rangeset.NewFromString[MetricsElementType]("{5:25,45,60}")
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = rangeset.Similarity(s1, s2)
+	})
+	Assertf(t, allocs == 0, "MetricsAllocs: expected no allocations got %v", allocs)
+}
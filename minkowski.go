@@ -0,0 +1,56 @@
+package rangeset
+
+// minkowski.go implements the Minkowski sum and difference of two sets.  These work with
+// pairs of ranges (one from each set) rather than individual elements, so they have time
+// complexity O(r1*r2 log(r1*r2)) where r1 and r2 are the number of ranges in each set.
+
+// MinkowskiSum returns the set of all sums of an element of a and an element of b, ie
+// {x+y : x in a, y in b}.  Sums that are outside the range of the element type are handled
+// according to the Overflow policy - if the policy is OverflowError and any sum overflows
+// then ErrOverflow is returned (and a nil set).
+func MinkowskiSum[T Element](a, b Set[T], policy Overflow) (Set[T], error) {
+	spans := make([]Span[T], 0, len(a)*len(b))
+	for _, va := range a {
+		for _, vb := range b {
+			// The sums of the elements of two ranges form a single range
+			lo := toInt128(va.Bot).add(toInt128(vb.Bot))
+			hi := toInt128(va.Top - 1).add(toInt128(vb.Top - 1))
+			var err error
+			if spans, err = appendOverflow(spans, lo, hi, policy); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return normalize(spans), nil
+}
+
+// MinkowskiDifference returns the erosion of a by b - the set of all elements x such that x+y
+// is in a for every element y of b.  It is the largest set whose Minkowski sum with b is a
+// subset of a.  (Sums that are outside the range of the element type are not in a, so
+// overflow does not need to be handled.)  If b is empty the universal set is returned.
+func MinkowskiDifference[T Element](a, b Set[T]) Set[T] {
+	minElt, maxElt := toInt128(minInt[T]()), toInt128(maxInt[T]())
+	eroded := make([]Set[T], 0, len(b))
+	for _, vb := range b {
+		// x+[b1, b2] is within a range [a1, a2] of a when x is in [a1-b1, a2-b2]
+		retval := Set[T]{}
+		for _, va := range a {
+			lo := toInt128(va.Bot).add(toInt128(vb.Bot).neg())
+			hi := toInt128(va.Top - 1).add(toInt128(vb.Top - 1).neg())
+			if lo.less(minElt) {
+				lo = minElt
+			}
+			if maxElt.less(hi) {
+				hi = maxElt
+			}
+			if !hi.less(lo) {
+				retval = appendSpan(retval, T(lo.lo), T(hi.lo))
+			}
+		}
+		eroded = append(eroded, retval)
+	}
+	if len(eroded) == 0 {
+		return Universal[T]()
+	}
+	return Intersect(eroded...)
+}
@@ -0,0 +1,101 @@
+package rangeset_test
+
+import (
+	"github.com/andrewwphillips/rangeset"
+	"math/rand/v2"
+	"testing"
+)
+
+type MinkowskiElementType int
+
+// minkowskiData is for table-driven tests of MinkowskiSum and MinkowskiDifference
+var minkowskiData = map[string]struct {
+	a, b        string
+	sum, diff   string
+	sumOverflow bool // MinkowskiSum overflows (so OverflowError policy returns ErrOverflow)
+}{
+	"Empty":     {"{}", "{}", "{}", "{U}", false},
+	"EmptyA":    {"{}", "{1:3}", "{}", "{}", false},
+	"EmptyB":    {"{1:3}", "{}", "{}", "{U}", false},
+	"Zero":      {"{1:3,7}", "{0}", "{1:3,7}", "{1:3,7}", false},
+	"Offsets":   {"{10,20}", "{0:2}", "{10:12,20:22}", "{}", false},
+	"Schedule":  {"{0:9,20:29}", "{0,5}", "{0:14,20:34}", "{0:4,20:24}", false},
+	"Negative":  {"{-5:5}", "{-2,2}", "{-7:7}", "{-3:3}", false},
+	"Joins":     {"{1,5,9}", "{0:3}", "{1:12}", "{}", false},
+	"Overflow":  {"{1:E}", "{1}", "{2:E}", "{0:9223372036854775806}", true},
+	"Universal": {"{U}", "{1:3}", "{-9223372036854775807:E}", "{-9223372036854775808:9223372036854775804}", true},
+}
+
+// TestMinkowskiTable tests MinkowskiSum and MinkowskiDifference using the minkowskiData table
+func TestMinkowskiTable(t *testing.T) {
+	for name, data := range minkowskiData {
+		a, _ := rangeset.NewFromString[MinkowskiElementType](data.a)
+		b, _ := rangeset.NewFromString[MinkowskiElementType](data.b)
+		expected, _ := rangeset.NewFromString[MinkowskiElementType](data.sum)
+		got, err := rangeset.MinkowskiSum(a, b, rangeset.OverflowClip)
+		Assertf(t, err == nil && rangeset.Equal(got, expected), "MinkowskiSum: %12s: expected %v got %v (%v)", name, expected, got, err)
+
+		got, err = rangeset.MinkowskiSum(a, b, rangeset.OverflowError)
+		if data.sumOverflow {
+			Assertf(t, err == rangeset.ErrOverflow, "MinkowskiSum: %12s: expected ErrOverflow got %v", name, err)
+		} else {
+			Assertf(t, err == nil && rangeset.Equal(got, expected), "MinkowskiSum: %12s: expected %v got %v (%v)", name, expected, got, err)
+		}
+
+		expected, _ = rangeset.NewFromString[MinkowskiElementType](data.diff)
+		got = rangeset.MinkowskiDifference(a, b)
+		Assertf(t, rangeset.Equal(got, expected), "MinkowskiDifference: %12s: expected %v got %v", name, expected, got)
+	}
+}
+
+// testMinkowskiBruteForce checks MinkowskiSum and MinkowskiDifference against adding every pair of elements
+func testMinkowskiBruteForce[T int8 | uint8](t *testing.T, name string) {
+	minElt, maxElt := int(rangeset.Universal[T]()[0].Bot), int(rangeset.Universal[T]()[0].Top-1)
+	r := rand.New(rand.NewPCG(47, 48))
+	for range 100 {
+		a, b := randomSet[T](r), randomSet[T](r)
+		if r.IntN(2) == 0 {
+			b = rangeset.Make(T(r.IntN(256)), T(r.IntN(256)), T(r.IntN(256))) // a few offsets
+		}
+		var clip, wrap rangeset.Set[T]
+		overflow := false
+		for _, x := range elements(a) {
+			for _, y := range elements(b) {
+				sum := int(x) + int(y)
+				if sum >= minElt && sum <= maxElt {
+					clip.Add(T(sum))
+				} else {
+					overflow = true
+				}
+				wrap.Add(T(sum))
+			}
+		}
+		bElts := elements(b)
+		diff := bruteForce(func(x T) bool {
+			for _, y := range bElts {
+				sum := int(x) + int(y)
+				if sum < minElt || sum > maxElt || !a.Contains(T(sum)) {
+					return false
+				}
+			}
+			return true
+		})
+
+		got, err := rangeset.MinkowskiSum(a, b, rangeset.OverflowClip)
+		Assertf(t, err == nil && rangeset.Equal(got, clip), "%s MinkowskiSum: %v + %v expected %v got %v", name, a, b, clip, got)
+		got, err = rangeset.MinkowskiSum(a, b, rangeset.OverflowWrap)
+		Assertf(t, err == nil && rangeset.Equal(got, wrap), "%s MinkowskiSum(wrap): %v + %v expected %v got %v", name, a, b, wrap, got)
+		got, err = rangeset.MinkowskiSum(a, b, rangeset.OverflowError)
+		Assertf(t, (err == rangeset.ErrOverflow) == overflow && (overflow || rangeset.Equal(got, clip)),
+			"%s MinkowskiSum(error): %v + %v expected %v (overflow %t) got %v (%v)", name, a, b, clip, overflow, got, err)
+
+		got = rangeset.MinkowskiDifference(a, b)
+		Assertf(t, rangeset.Equal(got, diff), "%s MinkowskiDifference: %v - %v expected %v got %v", name, a, b, diff, got)
+	}
+}
+
+// TestMinkowskiBruteForce tests MinkowskiSum and MinkowskiDifference using 8-bit elements
+func TestMinkowskiBruteForce(t *testing.T) {
+	testMinkowskiBruteForce[int8](t, "int8")
+	testMinkowskiBruteForce[uint8](t, "uint8")
+}
@@ -0,0 +1,66 @@
+package rangeset
+
+// morph.go implements "morphological" operations that grow or shrink the ranges of a set (or
+// remove small ranges or gaps).  These modify the set in place and have time complexity O(r).
+// Ranges do not wrap around at the ends of the element type - eg a range that is grown
+// past the largest element stops at the largest element.  Values beyond the ends of the
+// element type are treated as not being in the set.
+
+// Dilate grows every range of the set by k elements at both ends, joining any ranges that then
+// overlap or are adjacent.  (So any gap of less than 2k+1 elements is removed.)
+func (s *Set[T]) Dilate(k uint64) {
+	var endMark = minInt[T]() // in a range it flags: bottom/top of all valid elements
+	retval := (*s)[:0]        // ranges are written over the original ones (never ahead of them)
+	for _, v := range *s {
+		b, last := subSat(v.Bot, k), addSat(v.Top-1, k)
+		if n := len(retval); n > 0 && (retval[n-1].Top == endMark || b <= retval[n-1].Top) {
+			retval[n-1].Top = last + 1 // join onto previous range
+			continue
+		}
+		retval = append(retval, Span[T]{b, last + 1}) // last+1 wraps to the end-mark if last is maxInt
+	}
+	*s = retval
+}
+
+// Erode shrinks every range of the set by k elements at both ends, removing any ranges that
+// have 2k or fewer elements.  Values beyond the ends of the element type are treated as not
+// in the set, so ranges are also shrunk at the ends of the type (eg Erode of the universal
+// set removes k elements from each end) and Dilate(k) after Erode(k) (an opening) never
+// adds elements.
+func (s *Set[T]) Erode(k uint64) {
+	retval := (*s)[:0]
+	for _, v := range *s {
+		b, last := v.Bot, v.Top-1
+		// Check that some elements remain (without overflow)
+		if d := distance(b, last); k > d || k > d-k {
+			continue
+		}
+		retval = append(retval, Span[T]{b + T(k), last - T(k) + 1})
+	}
+	*s = retval
+}
+
+// CloseGaps fills in all gaps (between ranges of the set) of less than k elements
+func (s *Set[T]) CloseGaps(k uint64) {
+	retval := (*s)[:0]
+	for _, v := range *s {
+		if n := len(retval); n > 0 && distance(retval[n-1].Top, v.Bot) < k {
+			retval[n-1].Top = v.Top
+			continue
+		}
+		retval = append(retval, v)
+	}
+	*s = retval
+}
+
+// OpenSpans removes all ranges of the set that have less than k elements
+func (s *Set[T]) OpenSpans(k uint64) {
+	retval := (*s)[:0]
+	for _, v := range *s {
+		if n := rangeLen(v.Bot, v.Top-1); n != 0 && n < k { // n == 0 means 2^64 elements
+			continue
+		}
+		retval = append(retval, v)
+	}
+	*s = retval
+}
@@ -0,0 +1,164 @@
+package rangeset_test
+
+import (
+	"github.com/andrewwphillips/rangeset"
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+type MorphElementType int
+
+// morphData is for table-driven tests of Dilate, Erode, CloseGaps and OpenSpans
+var morphData = map[string]struct {
+	in                         string
+	k                          uint64
+	dilate, erode, close, open string
+}{
+	"Empty":     {"{}", 2, "{}", "{}", "{}", "{}"},
+	"Zero":      {"{1,3:5}", 0, "{1,3:5}", "{1,3:5}", "{1,3:5}", "{1,3:5}"},
+	"One":       {"{1,3:5,8:20}", 1, "{0:6,7:21}", "{4,9:19}", "{1,3:5,8:20}", "{1,3:5,8:20}"},
+	"Two":       {"{1,3:5,8:20}", 2, "{-1:22}", "{10:18}", "{1:5,8:20}", "{3:5,8:20}"},
+	"Three":     {"{1,3:5,8:20}", 3, "{-2:23}", "{11:17}", "{1:20}", "{3:5,8:20}"},
+	"Big":       {"{1,3:5,8:20}", 100, "{-99:120}", "{}", "{1:20}", "{}"},
+	"Universal": {"{U}", 1000, "{U}", "{-9223372036854774808:9223372036854774807}", "{U}", "{U}"},
+	"Ends":      {"{-9223372036854775808:-10,10:E}", 5, "{-9223372036854775808:-5,5:E}", "{-9223372036854775803:-15,15:9223372036854775802}", "{-9223372036854775808:-10,10:E}", "{-9223372036854775808:-10,10:E}"},
+	"Huge":      {"{-9223372036854775808:-10,0,10:E}", math.MaxUint64, "{U}", "{}", "{U}", "{}"},
+}
+
+// TestMorphTable tests Dilate, Erode, CloseGaps and OpenSpans using the morphData table
+func TestMorphTable(t *testing.T) {
+	for name, data := range morphData {
+		in, err := rangeset.NewFromString[MorphElementType](data.in)
+		Assertf(t, err == nil, "Morph: %12s: error %v decoding %q", name, err, data.in)
+		for op, expectedStr := range map[string]string{"Dilate": data.dilate, "Erode": data.erode,
+			"CloseGaps": data.close, "OpenSpans": data.open} {
+			expected, err := rangeset.NewFromString[MorphElementType](expectedStr)
+			Assertf(t, err == nil, "Morph: %12s: error %v decoding %q", name, err, expectedStr)
+			got := in.Copy()
+			switch op {
+			case "Dilate":
+				got.Dilate(data.k)
+			case "Erode":
+				got.Erode(data.k)
+			case "CloseGaps":
+				got.CloseGaps(data.k)
+			case "OpenSpans":
+				got.OpenSpans(data.k)
+			}
+			Assertf(t, rangeset.Equal(got, expected), "%s: %12s: %v k=%d expected %v got %v", op, name, in, data.k, expected, got)
+		}
+	}
+}
+
+// TestMorphUnsigned tests that unsigned sets saturate at the ends of the type
+func TestMorphUnsigned(t *testing.T) {
+	s := rangeset.Make[uint64](2, math.MaxUint64-2)
+	s.Dilate(5)
+	expected := rangeset.NewFromRange[uint64](0, 8)
+	expected.AddRange(math.MaxUint64-7, 0) // 0 is the end-mark
+	Assertf(t, rangeset.Equal(s, expected), "MorphUnsigned: Dilate expected %v got %v", expected, s)
+	s.Erode(5)
+	Assertf(t, len(s) == 0, "MorphUnsigned: Erode expected empty set got %v", s)
+
+	s = rangeset.Universal[uint64]()
+	s.Erode(5)
+	expected = rangeset.NewFromRange[uint64](5, math.MaxUint64-4)
+	Assertf(t, rangeset.Equal(s, expected), "MorphUnsigned: Erode expected %v got %v", expected, s)
+
+	s16 := rangeset.Make[uint16](100, 200)
+	s16.Dilate(math.MaxUint64)
+	Assertf(t, rangeset.Equal(s16, rangeset.Universal[uint16]()), "MorphUnsigned: Dilate expected universal got %v", s16)
+	s16 = rangeset.Make[uint16](100, 200)
+	s16.Erode(1)
+	Assertf(t, len(s16) == 0, "MorphUnsigned: Erode expected empty set got %v", s16)
+}
+
+// within returns true if e is no further than k from x
+func within[T int8 | uint8](e, x T, k uint64) bool {
+	return uint64(max(int(e)-int(x), int(x)-int(e))) <= k
+}
+
+// testMorphBruteForce checks the morphological operations against brute force calculations
+func testMorphBruteForce[T int8 | uint8](t *testing.T, name string) {
+	r := rand.New(rand.NewPCG(41, 42))
+	all := elements(rangeset.Universal[T]())
+	minElt, maxElt := int(all[0]), int(all[len(all)-1])
+	for range 100 {
+		s := randomSet[T](r)
+		k := uint64(r.IntN(10))
+		if r.IntN(10) == 0 {
+			k = uint64(r.IntN(300))
+		}
+
+		dilate := bruteForce(func(e T) bool {
+			for _, x := range all {
+				if within(e, x, k) && s.Contains(x) {
+					return true
+				}
+			}
+			return false
+		})
+		erode := bruteForce(func(e T) bool {
+			if int(e)-int(k) < minElt || int(e)+int(k) > maxElt {
+				return false // values beyond the ends of the type are not in the set
+			}
+			for _, x := range all {
+				if within(e, x, k) && !s.Contains(x) {
+					return false
+				}
+			}
+			return true
+		})
+		closeGaps := bruteForce(func(e T) bool {
+			if s.Contains(e) {
+				return true
+			}
+			below, ok1 := s.Floor(e)
+			above, ok2 := s.Ceil(e)
+			return ok1 && ok2 && uint64(above-below-1) < k
+		})
+		openSpans := bruteForce(func(e T) bool {
+			if !s.Contains(e) {
+				return false
+			}
+			// Count the elements in the range containing e
+			n := uint64(1)
+			for x := e - 1; x < e && s.Contains(x); x-- {
+				n++
+			}
+			for x := e + 1; x > e && s.Contains(x); x++ {
+				n++
+			}
+			return n >= k
+		})
+
+		for op, expected := range map[string]rangeset.Set[T]{"Dilate": dilate, "Erode": erode,
+			"CloseGaps": closeGaps, "OpenSpans": openSpans} {
+			got := s.Copy()
+			switch op {
+			case "Dilate":
+				got.Dilate(k)
+			case "Erode":
+				got.Erode(k)
+			case "CloseGaps":
+				got.CloseGaps(k)
+			case "OpenSpans":
+				got.OpenSpans(k)
+			}
+			Assertf(t, rangeset.Equal(got, expected), "%s %s: %v k=%d expected %v got %v", name, op, s, k, expected, got)
+		}
+
+		// An opening (Erode then Dilate) never adds elements
+		opening := s.Copy()
+		opening.Erode(k)
+		opening.Dilate(k)
+		Assertf(t, opening.IsSubsetOf(s), "%s Opening: %v k=%d added elements %v", name, s, k, opening)
+	}
+}
+
+// TestMorphBruteForce tests the morphological operations using 8-bit elements
+func TestMorphBruteForce(t *testing.T) {
+	testMorphBruteForce[int8](t, "int8")
+	testMorphBruteForce[uint8](t, "uint8")
+}
@@ -0,0 +1,99 @@
+package rangeset
+
+// navigate.go implements methods to find elements of the set relative to a value, such as
+// the smallest element or the next element after a value.  These all return false as their
+// 2nd return value if there is no such element.  Since they use a binary search they have
+// time complexity O(log r) where r is the number of ranges (or better).
+
+// Min returns the smallest element of the set
+func (s Set[T]) Min() (T, bool) {
+	if len(s) == 0 {
+		return 0, false
+	}
+	return s[0].Bot, true
+}
+
+// Max returns the largest element of the set
+func (s Set[T]) Max() (T, bool) {
+	if len(s) == 0 {
+		return 0, false
+	}
+	return s[len(s)-1].Top - 1, true // Top-1 wraps around to the largest element if Top is the end-mark
+}
+
+// Ceil returns the smallest element of the set that is greater than or equal to e
+func (s Set[T]) Ceil(e T) (T, bool) {
+	idx := s.bsearch(e)
+	if idx > 0 && e <= s[idx-1].Top-1 {
+		return e, true // e is in the set
+	}
+	if idx < len(s) {
+		return s[idx].Bot, true
+	}
+	return 0, false
+}
+
+// Floor returns the largest element of the set that is less than or equal to e
+func (s Set[T]) Floor(e T) (T, bool) {
+	idx := s.bsearch(e)
+	if idx == 0 {
+		return 0, false
+	}
+	return min(e, s[idx-1].Top-1), true
+}
+
+// Next returns the smallest element of the set that is greater than e
+func (s Set[T]) Next(e T) (T, bool) {
+	if e == maxInt[T]() {
+		return 0, false
+	}
+	return s.Ceil(e + 1)
+}
+
+// Prev returns the largest element of the set that is less than e
+func (s Set[T]) Prev(e T) (T, bool) {
+	if e == minInt[T]() {
+		return 0, false
+	}
+	return s.Floor(e - 1)
+}
+
+// Nearest returns the element of the set that is closest to e.  If two elements are equally close
+// (one below e and one above) then the lower one is returned.
+func (s Set[T]) Nearest(e T) (T, bool) {
+	below, okBelow := s.Floor(e)
+	above, okAbove := s.Ceil(e)
+	if okBelow && (!okAbove || distance(below, e) <= distance(e, above)) {
+		return below, true
+	}
+	return above, okAbove
+}
+
+// PopMin removes the smallest element of the set and returns it
+func (s *Set[T]) PopMin() (T, bool) {
+	if len(*s) == 0 {
+		return 0, false
+	}
+	e := (*s)[0].Bot
+	if (*s)[0].Top == e+1 {
+		*s = (*s)[1:] // remove the range as it only has one element
+	} else {
+		(*s)[0].Bot++
+	}
+	return e, true
+}
+
+// PopMax removes the largest element of the set and returns it
+func (s *Set[T]) PopMax() (T, bool) {
+	n := len(*s)
+	if n == 0 {
+		return 0, false
+	}
+	e := (*s)[n-1].Top - 1
+	if (*s)[n-1].Bot == e {
+		*s = (*s)[:n-1] // remove the range as it only has one element
+	} else {
+		(*s)[n-1].Top = e
+	}
+	return e, true
+}
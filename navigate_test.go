@@ -0,0 +1,111 @@
+package rangeset_test
+
+import (
+	"github.com/andrewwphillips/rangeset"
+	"math/rand/v2"
+	"testing"
+)
+
+// TestNavigateUniversal checks Min and Max etc of universal sets, where the top range has the end-mark
+func TestNavigateUniversal(t *testing.T) {
+	u := rangeset.Universal[uint8]()
+	got, ok := u.Max()
+	Assertf(t, ok && got == 255, "NavigateUniversal: expected Max of 255 got %d (%t)", got, ok)
+	got, ok = u.Min()
+	Assertf(t, ok && got == 0, "NavigateUniversal: expected Min of 0 got %d (%t)", got, ok)
+	got, ok = u.Next(254)
+	Assertf(t, ok && got == 255, "NavigateUniversal: expected Next(254) of 255 got %d (%t)", got, ok)
+	_, ok = u.Next(255)
+	Assertf(t, !ok, "NavigateUniversal: expected no Next(255)")
+	_, ok = u.Prev(0)
+	Assertf(t, !ok, "NavigateUniversal: expected no Prev(0)")
+	got, ok = u.PopMax()
+	Assertf(t, ok && got == 255 && u.String() == "{0:254}", "NavigateUniversal: expected PopMax of 255 got %d (%t) leaving %v", got, ok, u)
+
+	s := rangeset.Universal[int64]()
+	got64, ok := s.Max()
+	Assertf(t, ok && got64 == 1<<63-1, "NavigateUniversal: expected Max of %d got %d (%t)", int64(1<<63-1), got64, ok)
+	got64, ok = s.PopMin()
+	Assertf(t, ok && got64 == -1<<63, "NavigateUniversal: expected PopMin of %d got %d (%t)", int64(-1<<63), got64, ok)
+	got64, ok = s.Min()
+	Assertf(t, ok && got64 == -1<<63+1, "NavigateUniversal: expected Min of %d got %d (%t)", int64(-1<<63+1), got64, ok)
+}
+
+// TestNavigateEmpty checks the navigation methods on an empty set
+func TestNavigateEmpty(t *testing.T) {
+	var s rangeset.Set[int]
+	_, ok1 := s.Min()
+	_, ok2 := s.Max()
+	_, ok3 := s.Nearest(0)
+	_, ok4 := s.PopMin()
+	_, ok5 := s.PopMax()
+	Assertf(t, !ok1 && !ok2 && !ok3 && !ok4 && !ok5, "NavigateEmpty: expected no elements")
+}
+
+// testNavigateBruteForce checks the navigation methods against brute force searches
+func testNavigateBruteForce[T int8 | uint8](t *testing.T, name string) {
+	r := rand.New(rand.NewPCG(17, 18))
+	for range 40 {
+		s := randomSet[T](r)
+		elts := elements(s)
+		for i := 0; i < 256; i++ {
+			e := T(i)
+			var next, prev, ceil, floor, nearest *T
+			for idx := range elts {
+				if elts[idx] > e && next == nil {
+					next = &elts[idx]
+				}
+				if elts[idx] >= e && ceil == nil {
+					ceil = &elts[idx]
+				}
+				if elts[idx] < e {
+					prev = &elts[idx]
+				}
+				if elts[idx] <= e {
+					floor = &elts[idx]
+				}
+			}
+			nearest = floor
+			if ceil != nil && (floor == nil || int(*ceil)-int(e) < int(e)-int(*floor)) {
+				nearest = ceil
+			}
+			check := func(method string, expected *T, got T, ok bool) {
+				if expected == nil {
+					Assertf(t, !ok, "%s %s(%d): %v: expected nothing got %d", name, method, e, s, got)
+				} else {
+					Assertf(t, ok && got == *expected, "%s %s(%d): %v: expected %d got %d (%t)", name, method, e, s, *expected, got, ok)
+				}
+			}
+			got, ok := s.Next(e)
+			check("Next", next, got, ok)
+			got, ok = s.Prev(e)
+			check("Prev", prev, got, ok)
+			got, ok = s.Ceil(e)
+			check("Ceil", ceil, got, ok)
+			got, ok = s.Floor(e)
+			check("Floor", floor, got, ok)
+			got, ok = s.Nearest(e)
+			check("Nearest", nearest, got, ok)
+		}
+
+		// Pop all the elements (alternately from bottom and top)
+		for lo, hi := 0, len(elts)-1; lo <= hi; {
+			if r.IntN(2) == 0 {
+				got, ok := s.PopMin()
+				Assertf(t, ok && got == elts[lo], "%s PopMin: expected %d got %d (%t)", name, elts[lo], got, ok)
+				lo++
+			} else {
+				got, ok := s.PopMax()
+				Assertf(t, ok && got == elts[hi], "%s PopMax: expected %d got %d (%t)", name, elts[hi], got, ok)
+				hi--
+			}
+		}
+		Assertf(t, len(s) == 0, "%s Pop: expected empty set got %v", name, s)
+	}
+}
+
+// TestNavigateBruteForce tests the navigation methods using 8-bit elements
+func TestNavigateBruteForce(t *testing.T) {
+	testNavigateBruteForce[int8](t, "int8")
+	testNavigateBruteForce[uint8](t, "uint8")
+}
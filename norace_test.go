@@ -0,0 +1,6 @@
+//go:build !race
+
+package rangeset_test
+
+// raceEnabled is true when the tests are run with the race detector (see race_test.go)
+const raceEnabled = false
@@ -2,9 +2,24 @@ package rangeset
 
 // op.go implement set operations like union, etc
 
-// Equal compares two sets
-// TODO: extend to compare any number of sets
-func Equal[T Element](s1, s2 Set[T]) bool {
+import (
+	"math/big"
+	"runtime"
+	"sync"
+)
+
+// Equal returns true if all the sets are identical (or there are less than 2 sets)
+func Equal[T Element](sets ...Set[T]) bool {
+	for idx := 1; idx < len(sets); idx++ {
+		if !equal(sets[0], sets[idx]) {
+			return false
+		}
+	}
+	return true
+}
+
+// equal compares two sets
+func equal[T Element](s1, s2 Set[T]) bool {
 	if len(s1) != len(s2) {
 		return false
 	}
@@ -44,29 +59,147 @@ func Complement[T Element](s Set[T]) Set[T] {
 }
 
 // Union finds the union of zero or more sets and returns a new set
+// The sets are combined using a k-way merge which has time complexity O(r log k), where
+// r is the total number of ranges and k is the number of sets.
 func Union[T Element](sets ...Set[T]) Set[T] {
-	if len(sets) == 0 {
+	switch len(sets) {
+	case 0:
 		return Set[T]{} // return empty set
+	case 1:
+		return sets[0].Copy()
+	case 2:
+		return unionOf(sets[0], sets[1])
 	}
-	// Copy one set and add (union) all the other sets to it
-	// TODO: check if copying largest set then merging others is faster for common scenarios
-	retval := sets[0].Copy()
-	for _, other := range sets[1:] {
-		retval.AddSet(other)
-	}
-	return retval
+	return unionK(sets)
 }
 
 // Intersect finds the intersection of zero or more sets, returning a new set
+// Like Union (above) it uses a k-way merge with time complexity of O(r log k).
 func Intersect[T Element](sets ...Set[T]) Set[T] {
-	if len(sets) == 0 {
+	switch len(sets) {
+	case 0:
 		return Set[T]{} // return empty set
+	case 1:
+		return sets[0].Copy()
+	case 2:
+		return intersectionOf(sets[0], sets[1])
 	}
+	return intersectK(sets)
+}
 
-	retval := sets[0].Copy()
-	for _, other := range sets[1:] {
-		retval.Intersect(other)
+// SymmetricDifference returns a new set of the elements that are in an odd number of the
+// sets.  For two sets this is the elements in one set or the other but not both (XOR).
+// For two sets it has time complexity O(r1+r2), otherwise O(r log r) where r is the total
+// number of ranges in all the sets.
+func SymmetricDifference[T Element](sets ...Set[T]) Set[T] {
+	switch len(sets) {
+	case 0:
+		return Set[T]{} // return empty set
+	case 1:
+		return sets[0].Copy()
+	case 2:
+		return symmetricDifferenceOf(sets[0], sets[1])
 	}
+	return symmetricDifferenceK(sets)
+}
 
-	return retval
+// Difference returns a new set containing the elements of s that are not in any of the
+// other sets.  Unlike the SubSet method it does not modify s.
+func Difference[T Element](s Set[T], others ...Set[T]) Set[T] {
+	switch len(others) {
+	case 0:
+		return s.Copy()
+	case 1:
+		return differenceOf(s, others[0])
+	}
+	return differenceOf(s, Union(others...))
+}
+
+// IntersectionLength returns the number of elements and ranges in the intersection of s1 and
+// s2 - ie the same as Intersect(s1, s2).Length() but without creating the intersection.
+// It has time complexity O(r1+r2) and does not allocate memory.  Like Length(), if the
+// result is all the elements of a 64-bit type, 0 is returned for the number of elements.
+func IntersectionLength[T Element](s1, s2 Set[T]) (length uint64, spans int) {
+	c, spans := countSpans(s1, s2, intersectSpans[T])
+	return c.lo, spans
+}
+
+// UnionLength returns the number of elements and ranges in the union of s1 and s2 - ie the
+// same as Union(s1, s2).Length() but without creating the union (see IntersectionLength).
+func UnionLength[T Element](s1, s2 Set[T]) (length uint64, spans int) {
+	c, spans := countSpans(s1, s2, unionSpans[T])
+	return c.lo, spans
+}
+
+// DifferenceLength returns the number of elements and ranges of s1 that are not in s2 - ie
+// the same as Difference(s1, s2).Length() but without creating the difference (see IntersectionLength).
+func DifferenceLength[T Element](s1, s2 Set[T]) (length uint64, spans int) {
+	c, spans := countSpans(s1, s2, differenceSpans[T])
+	return c.lo, spans
+}
+
+// IntersectionLengthBig is the same as IntersectionLength but returns the exact number of elements
+func IntersectionLengthBig[T Element](s1, s2 Set[T]) (*big.Int, int) {
+	c, spans := countSpans(s1, s2, intersectSpans[T])
+	return c.bigInt(), spans
+}
+
+// UnionLengthBig is the same as UnionLength but returns the exact number of elements
+func UnionLengthBig[T Element](s1, s2 Set[T]) (*big.Int, int) {
+	c, spans := countSpans(s1, s2, unionSpans[T])
+	return c.bigInt(), spans
+}
+
+// DifferenceLengthBig is the same as DifferenceLength but returns the exact number of elements
+func DifferenceLengthBig[T Element](s1, s2 Set[T]) (*big.Int, int) {
+	c, spans := countSpans(s1, s2, differenceSpans[T])
+	return c.bigInt(), spans
+}
+
+// countSpans returns the exact number of elements and the number of ranges found by one of
+// the functions (such as intersectSpans) that walk the ranges of two sets
+func countSpans[T Element](s1, s2 Set[T], walk func(s1, s2 Set[T], yield func(b, last T) bool)) (length count, spans int) {
+	walk(s1, s2, func(b, last T) bool {
+		length.addLen(rangeLen(b, last))
+		spans++
+		return true
+	})
+	return
+}
+
+// ParallelUnion is the same as Union but shares the work between n goroutines (or
+// GOMAXPROCS goroutines if n <= 0).  Each goroutine finds the union of its share of the
+// sets, then these partial results are merged.  This is only worthwhile for many large sets.
+func ParallelUnion[T Element](n int, sets ...Set[T]) Set[T] {
+	return parallel(n, sets, Union[T])
+}
+
+// ParallelIntersect is the same as Intersect but shares the work between n goroutines
+// (or GOMAXPROCS goroutines if n <= 0) in the same way as ParallelUnion.
+func ParallelIntersect[T Element](n int, sets ...Set[T]) Set[T] {
+	return parallel(n, sets, Intersect[T])
+}
+
+// parallel uses a tree reduction to apply op to sets - splitting the sets into (up to) n
+// groups, applying op to each group in its own goroutine, then applying op to the results.
+func parallel[T Element](n int, sets []Set[T], op func(...Set[T]) Set[T]) Set[T] {
+	if n <= 0 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	n = min(n, len(sets)/2) // no point in a goroutine merging less than 2 sets
+	if n < 2 {
+		return op(sets...)
+	}
+
+	partial := make([]Set[T], n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			partial[i] = op(sets[i*len(sets)/n : (i+1)*len(sets)/n]...)
+		}()
+	}
+	wg.Wait()
+	return op(partial...)
 }
@@ -2,6 +2,7 @@ package rangeset_test
 
 import (
 	"github.com/andrewwphillips/rangeset"
+	"math/rand/v2"
 	"testing"
 )
 
@@ -227,3 +228,364 @@ func TestAddSetMethod(t *testing.T) {
 			name, expected, got)
 	}
 }
+
+// xorData provides table data for testing SymmetricDifference() (especially with universal sets)
+var xorData = map[string]struct {
+	in       []string
+	expected string
+}{
+	"Empty0":    {[]string{}, "{}"},
+	"Single":    {[]string{"{1:5}"}, "{1:5}"},
+	"Overlap":   {[]string{"{1:5}", "{4:8}"}, "{1:3,6:8}"},
+	"Touch":     {[]string{"{1:5}", "{6:8}"}, "{1:8}"},
+	"Same3":     {[]string{"{1:5}", "{1:5}", "{1:5}"}, "{1:5}"},
+	"UAndU":     {[]string{"{U}", "{U}"}, "{}"},
+	"UAndEmpty": {[]string{"{U}", "{}"}, "{U}"},
+	"UAndOne":   {[]string{"{U}", "{1}"}, "{E:0,2:E}"},
+	"OneAndU":   {[]string{"{1}", "{U}"}, "{E:0,2:E}"},
+	"U3":        {[]string{"{U}", "{U}", "{U}"}, "{U}"},
+	"UAndTwo":   {[]string{"{U}", "{4}", "{2}"}, "{E:1,3,5:E}"},
+	"EndAndEnd": {[]string{"{E:10}", "{5:E}"}, "{E:4,11:E}"},
+	"Ends3":     {[]string{"{E:10}", "{5:E}", "{7}"}, "{E:4,7,11:E}"},
+}
+
+// TestSymmetricDifference tests the SymmetricDifference function and method using the xorData table
+func TestSymmetricDifference(t *testing.T) {
+	for name, data := range xorData {
+		var sets []rangeset.Set[uint]
+		for _, str := range data.in {
+			s, _ := rangeset.NewFromString[uint](str)
+			sets = append(sets, s)
+		}
+		expected, _ := rangeset.NewFromString[uint](data.expected)
+		got := rangeset.SymmetricDifference(sets...)
+		Assertf(t, rangeset.Equal(got, expected), "SymmetricDifference: %12s: expected %q got %q\n",
+			name, expected, got)
+
+		if len(sets) == 0 {
+			continue
+		}
+		got = sets[0].Copy()
+		for _, other := range sets[1:] {
+			got.SymmetricDifference(other)
+		}
+		Assertf(t, rangeset.Equal(got, expected), "SymmetricDifference method: %12s: expected %q got %q\n",
+			name, expected, got)
+	}
+}
+
+// randomSet returns a set with a few random ranges, some of which may extend to the end of the element type
+func randomSet[T int8 | uint8](r *rand.Rand) rangeset.Set[T] {
+	endMark := rangeset.Universal[T]()[0].Top
+	var s rangeset.Set[T]
+	for range r.IntN(6) {
+		b := T(r.IntN(256))
+		length := 1 + r.IntN(40)
+		if r.IntN(4) == 0 {
+			s.Add(b)
+		} else if int(b)+length > int(endMark-1) {
+			s.AddRange(b, endMark)
+		} else {
+			s.AddRange(b, b+T(length))
+		}
+	}
+	return s
+}
+
+// bruteForce returns a (normalised) set of all the elements, of an 8-bit type, for which f returns true
+func bruteForce[T int8 | uint8](f func(T) bool) rangeset.Set[T] {
+	var s rangeset.Set[T]
+	for i := 0; i < 256; i++ {
+		if f(T(i)) {
+			s.Add(T(i))
+		}
+	}
+	return s
+}
+
+// testMergeBruteForce checks the set operation methods and functions against a brute force calculation
+func testMergeBruteForce[T int8 | uint8](t *testing.T, name string) {
+	endMark := rangeset.Universal[T]()[0].Top
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 2000 {
+		s1, s2 := randomSet[T](r), randomSet[T](r)
+
+		union := bruteForce(func(e T) bool { return s1.Contains(e) || s2.Contains(e) })
+		got := s1.Copy()
+		got.AddSet(s2)
+		Assertf(t, rangeset.Equal(got, union), "%s AddSet: %v + %v: expected %v got %v", name, s1, s2, union, got)
+		got = rangeset.Union(s1, s2, s1)
+		Assertf(t, rangeset.Equal(got, union), "%s Union: %v + %v: expected %v got %v", name, s1, s2, union, got)
+
+		intersect := bruteForce(func(e T) bool { return s1.Contains(e) && s2.Contains(e) })
+		got = s1.Copy()
+		got.Intersect(s2)
+		Assertf(t, rangeset.Equal(got, intersect), "%s Intersect: %v & %v: expected %v got %v", name, s1, s2, intersect, got)
+		got = rangeset.Intersect(s1, s2, s1)
+		Assertf(t, rangeset.Equal(got, intersect), "%s Intersect func: %v & %v: expected %v got %v", name, s1, s2, intersect, got)
+
+		sub := bruteForce(func(e T) bool { return s1.Contains(e) && !s2.Contains(e) })
+		got = s1.Copy()
+		got.SubSet(s2)
+		Assertf(t, rangeset.Equal(got, sub), "%s SubSet: %v - %v: expected %v got %v", name, s1, s2, sub, got)
+		got = rangeset.Difference(s1, s2)
+		Assertf(t, rangeset.Equal(got, sub), "%s Difference: %v - %v: expected %v got %v", name, s1, s2, sub, got)
+
+		xor := bruteForce(func(e T) bool { return s1.Contains(e) != s2.Contains(e) })
+		got = s1.Copy()
+		got.SymmetricDifference(s2)
+		Assertf(t, rangeset.Equal(got, xor), "%s SymmetricDifference: %v ^ %v: expected %v got %v", name, s1, s2, xor, got)
+		got = rangeset.SymmetricDifference(s1, s2)
+		Assertf(t, rangeset.Equal(got, xor), "%s SymmetricDifference func: %v ^ %v: expected %v got %v", name, s1, s2, xor, got)
+
+		// Toggle the elements of a random range
+		b, top := T(r.IntN(256)), T(r.IntN(256))
+		toggled := bruteForce(func(e T) bool { return s1.Contains(e) != (e >= b && (e < top || top == endMark)) })
+		if top <= b && top != endMark {
+			toggled = s1 // invalid range does nothing
+		}
+		got = s1.Copy()
+		got.ToggleRange(b, top)
+		Assertf(t, rangeset.Equal(got, toggled), "%s ToggleRange: %v [%d,%d): expected %v got %v", name, s1, b, top, toggled, got)
+	}
+}
+
+// TestMergeBruteForce compares the results of set operations with a brute force calculation
+// using signed and unsigned element types (including ranges that extend to the end-mark)
+func TestMergeBruteForce(t *testing.T) {
+	testMergeBruteForce[int8](t, "int8")
+	testMergeBruteForce[uint8](t, "uint8")
+}
+
+// testManyBruteForce checks the functions that combine many sets against brute force
+func testManyBruteForce[T int8 | uint8](t *testing.T, name string) {
+	r := rand.New(rand.NewPCG(3, 4))
+	for range 500 {
+		sets := make([]rangeset.Set[T], 1+r.IntN(12))
+		for i := range sets {
+			sets[i] = randomSet[T](r)
+			if r.IntN(3) > 0 {
+				sets[i].AddRange(100, 120) // make sure there is some overlap (for Intersect)
+			}
+		}
+		union := bruteForce(func(e T) bool {
+			for _, s := range sets {
+				if s.Contains(e) {
+					return true
+				}
+			}
+			return false
+		})
+		intersect := bruteForce(func(e T) bool {
+			for _, s := range sets {
+				if !s.Contains(e) {
+					return false
+				}
+			}
+			return true
+		})
+
+		xor := bruteForce(func(e T) bool {
+			count := 0
+			for _, s := range sets {
+				if s.Contains(e) {
+					count++
+				}
+			}
+			return count%2 == 1
+		})
+		sub := bruteForce(func(e T) bool {
+			for _, s := range sets[1:] {
+				if s.Contains(e) {
+					return false
+				}
+			}
+			return sets[0].Contains(e)
+		})
+
+		got := rangeset.SymmetricDifference(sets...)
+		Assertf(t, rangeset.Equal(got, xor), "%s SymmetricDifference of %d: expected %v got %v", name, len(sets), xor, got)
+		got = rangeset.Difference(sets[0], sets[1:]...)
+		Assertf(t, rangeset.Equal(got, sub), "%s Difference of %d: expected %v got %v", name, len(sets), sub, got)
+		got = rangeset.Union(sets...)
+		Assertf(t, rangeset.Equal(got, union), "%s Union of %d: expected %v got %v", name, len(sets), union, got)
+		got = rangeset.ParallelUnion(3, sets...)
+		Assertf(t, rangeset.Equal(got, union), "%s ParallelUnion of %d: expected %v got %v", name, len(sets), union, got)
+		got = rangeset.Intersect(sets...)
+		Assertf(t, rangeset.Equal(got, intersect), "%s Intersect of %d: expected %v got %v", name, len(sets), intersect, got)
+		got = rangeset.ParallelIntersect(0, sets...)
+		Assertf(t, rangeset.Equal(got, intersect), "%s ParallelIntersect of %d: expected %v got %v", name, len(sets), intersect, got)
+	}
+}
+
+// TestManyBruteForce compares the results of operations on many sets with a brute force calculation
+func TestManyBruteForce(t *testing.T) {
+	testManyBruteForce[int8](t, "int8")
+	testManyBruteForce[uint8](t, "uint8")
+}
+
+// benchSets returns many large sets for benchmarks.  If dense is false each set has many small
+// random ranges (so that the union is large); otherwise each set is one large range with many
+// random holes (so that the intersection is large).
+func benchSets(count, spans int, dense bool) []rangeset.Set[uint64] {
+	r := rand.New(rand.NewPCG(5, 6))
+	sets := make([]rangeset.Set[uint64], count)
+	for i := range sets {
+		if dense {
+			sets[i] = rangeset.NewFromRange[uint64](0, 1e9)
+		}
+		for range spans {
+			b := r.Uint64N(1e9)
+			if dense {
+				sets[i].DeleteRange(b, b+1+r.Uint64N(1e3))
+			} else {
+				sets[i].AddRange(b, b+1+r.Uint64N(1e3))
+			}
+		}
+	}
+	return sets
+}
+
+// unionFold finds the union of sets by folding them into a copy of the first set one at a time.
+// It is the original (pre k-way merge) algorithm, which adds each range with AddRange, kept
+// here so that the benchmarks compare against it (rather than the newer AddSet).
+func unionFold(sets []rangeset.Set[uint64]) rangeset.Set[uint64] {
+	retval := sets[0].Copy()
+	for _, other := range sets[1:] {
+		for _, v := range other {
+			retval.AddRange(v.Bot, v.Top)
+		}
+	}
+	return retval
+}
+
+// intersectFold finds the intersection of sets by folding them into a copy of the first set one
+// at a time.  Like unionFold it uses the original algorithm, which deletes each gap between the
+// ranges of the other set with DeleteRange.
+func intersectFold(sets []rangeset.Set[uint64]) rangeset.Set[uint64] {
+	const endMark = 0 // uint64 end-mark
+	retval := sets[0].Copy()
+	for _, other := range sets[1:] {
+		bDel := uint64(endMark)
+		for _, v := range other {
+			if bDel != endMark || v.Bot != endMark {
+				retval.DeleteRange(bDel, v.Bot)
+			}
+			bDel = v.Top
+		}
+		if len(other) == 0 || bDel != endMark {
+			retval.DeleteRange(bDel, endMark)
+		}
+	}
+	return retval
+}
+
+func BenchmarkUnionFold(b *testing.B) {
+	sets := benchSets(200, 1000, false)
+	b.ResetTimer()
+	for range b.N {
+		_ = unionFold(sets)
+	}
+}
+
+func BenchmarkUnion(b *testing.B) {
+	sets := benchSets(200, 1000, false)
+	b.ResetTimer()
+	for range b.N {
+		_ = rangeset.Union(sets...)
+	}
+}
+
+func BenchmarkParallelUnion(b *testing.B) {
+	sets := benchSets(200, 1000, false)
+	b.ResetTimer()
+	for range b.N {
+		_ = rangeset.ParallelUnion(0, sets...)
+	}
+}
+
+func BenchmarkIntersectFold(b *testing.B) {
+	sets := benchSets(200, 1000, true)
+	b.ResetTimer()
+	for range b.N {
+		_ = intersectFold(sets)
+	}
+}
+
+func BenchmarkIntersect(b *testing.B) {
+	sets := benchSets(200, 1000, true)
+	b.ResetTimer()
+	for range b.N {
+		_ = rangeset.Intersect(sets...)
+	}
+}
+
+func BenchmarkParallelIntersect(b *testing.B) {
+	sets := benchSets(200, 1000, true)
+	b.ResetTimer()
+	for range b.N {
+		_ = rangeset.ParallelIntersect(0, sets...)
+	}
+}
+
+// testLengthBruteForce checks the cardinality-only functions against the length of the actual result
+func testLengthBruteForce[T int8 | uint8](t *testing.T, name string) {
+	r := rand.New(rand.NewPCG(9, 10))
+	for range 2000 {
+		s1, s2 := randomSet[T](r), randomSet[T](r)
+
+		expLength, expSpans := rangeset.Intersect(s1, s2).Length()
+		length, spans := rangeset.IntersectionLength(s1, s2)
+		Assertf(t, length == expLength && spans == expSpans, "%s IntersectionLength: %v & %v: expected %d,%d got %d,%d",
+			name, s1, s2, expLength, expSpans, length, spans)
+		expLength, expSpans = rangeset.Union(s1, s2).Length()
+		length, spans = rangeset.UnionLength(s1, s2)
+		Assertf(t, length == expLength && spans == expSpans, "%s UnionLength: %v + %v: expected %d,%d got %d,%d",
+			name, s1, s2, expLength, expSpans, length, spans)
+		expLength, expSpans = rangeset.Difference(s1, s2).Length()
+		length, spans = rangeset.DifferenceLength(s1, s2)
+		Assertf(t, length == expLength && spans == expSpans, "%s DifferenceLength: %v - %v: expected %d,%d got %d,%d",
+			name, s1, s2, expLength, expSpans, length, spans)
+		Assertf(t, int(expLength) == len(elements(rangeset.Difference(s1, s2))), "%s DifferenceLength: %v - %v: got %d",
+			name, s1, s2, expLength)
+	}
+}
+
+// TestLengthBruteForce tests IntersectionLength, UnionLength and DifferenceLength using 8-bit elements
+func TestLengthBruteForce(t *testing.T) {
+	testLengthBruteForce[int8](t, "int8")
+	testLengthBruteForce[uint8](t, "uint8")
+}
+
+// TestLength64 tests the cardinality-only functions with 64-bit universal sets
+func TestLength64(t *testing.T) {
+	u := rangeset.Universal[uint64]()
+	s := rangeset.NewFromRange[uint64](10, 20)
+	length, spans := rangeset.UnionLength(u, s)
+	Assertf(t, length == 0 && spans == 1, "Length64: union expected 0,1 got %d,%d", length, spans)
+	length, spans = rangeset.IntersectionLength(u, u)
+	Assertf(t, length == 0 && spans == 1, "Length64: intersection expected 0,1 got %d,%d", length, spans)
+	length, spans = rangeset.IntersectionLength(u, s)
+	Assertf(t, length == 10 && spans == 1, "Length64: intersection expected 10,1 got %d,%d", length, spans)
+	length, spans = rangeset.DifferenceLength(u, s)
+	Assertf(t, length == ^uint64(0)-9 && spans == 2, "Length64: difference expected %d,2 got %d,%d", ^uint64(0)-9, length, spans)
+
+	i := rangeset.Universal[int64]()
+	length, spans = rangeset.DifferenceLength(i, rangeset.Make[int64](0))
+	Assertf(t, length == ^uint64(0) && spans == 2, "Length64: signed difference expected %d,2 got %d,%d", ^uint64(0), length, spans)
+}
+
+// TestLengthAllocs checks that the cardinality-only functions do not allocate memory
+func TestLengthAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocations are not counted reliably with the race detector")
+	}
+	s1, _ := rangeset.NewFromString[uint]("{1:10,20:30,40:50}")
+	s2, _ := rangeset.NewFromString[uint]("{5:25,45,60}")
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = rangeset.IntersectionLength(s1, s2)
+		_, _ = rangeset.UnionLength(s1, s2)
+		_, _ = rangeset.DifferenceLength(s1, s2)
+	})
+	Assertf(t, allocs == 0, "LengthAllocs: expected no allocations got %v", allocs)
+}
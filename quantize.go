@@ -0,0 +1,104 @@
+package rangeset
+
+// quantize.go implements methods that convert between a set of elements and a set of "blocks"
+// of k consecutive elements, where block q contains the elements q*k to q*k+k-1 (so elements
+// are mapped to blocks using division rounded down, even for negative elements).
+// These modify the set in place and have time complexity O(r).
+
+// Quotient replaces every element e of the set with e/k (rounded down) - ie the set becomes
+// the set of blocks (of k elements) that contain at least one element.  If k <= 0 the set is
+// not modified and ErrInvalidRange is returned.
+func (s *Set[T]) Quotient(k T) error {
+	if k <= 0 {
+		return ErrInvalidRange
+	}
+	if k == 1 {
+		return nil
+	}
+	retval := (*s)[:0] // ranges are written over the original ones (never ahead of them)
+	for _, v := range *s {
+		b, last := floorDiv(v.Bot, k), floorDiv(v.Top-1, k) // since k > 1, last+1 can't overflow
+		if n := len(retval); n > 0 && b <= retval[n-1].Top {
+			retval[n-1].Top = last + 1 // join onto the previous range
+			continue
+		}
+		retval = append(retval, Span[T]{b, last + 1})
+	}
+	*s = retval
+	return nil
+}
+
+// Expand is the inverse of Quotient - every element q of the set is replaced by the k elements
+// of block q.  Any elements that are outside the range of the element type are ignored.
+// If k <= 0 the set is not modified and ErrInvalidRange is returned.
+func (s *Set[T]) Expand(k T) error {
+	if k <= 0 {
+		return ErrInvalidRange
+	}
+	qMin, qMax := floorDiv(minInt[T](), k), floorDiv(maxInt[T](), k)
+	retval := (*s)[:0]
+	for _, v := range *s {
+		// Ignore blocks that have no valid elements
+		qb, qe := max(v.Bot, qMin), min(v.Top-1, qMax)
+		if qb <= qe {
+			retval = append(retval, expandBlocks(qb, qe, k))
+		}
+	}
+	*s = retval
+	return nil
+}
+
+// AlignOut extends every range of the set down and up to a multiple of k, so that the set only
+// contains whole blocks (of k elements).  Equivalent to Quotient(k) then Expand(k).
+// If k <= 0 the set is not modified and ErrInvalidRange is returned.
+func (s *Set[T]) AlignOut(k T) error {
+	if err := s.Quotient(k); err != nil {
+		return err
+	}
+	return s.Expand(k)
+}
+
+// AlignIn shrinks every range of the set to the whole blocks (of k elements) that it contains,
+// removing ranges that do not contain a whole block.  (A block that is partly outside the range
+// of the element type is treated as whole if all its valid elements are in the set.)
+// If k <= 0 the set is not modified and ErrInvalidRange is returned.
+func (s *Set[T]) AlignIn(k T) error {
+	if k <= 0 {
+		return ErrInvalidRange
+	}
+	minElt, maxElt := minInt[T](), maxInt[T]()
+	qMin, qMax := floorDiv(minElt, k), floorDiv(maxElt, k)
+	retval := (*s)[:0]
+	for _, v := range *s {
+		// Find the first and last blocks that are entirely within the range
+		qb, qe := qMin, qMax
+		if v.Bot != minElt {
+			qb = floorDiv(v.Bot-1, k) + 1
+		}
+		if last := v.Top - 1; last != maxElt {
+			// Block after the last whole block (checked before subtracting to avoid wrap-around)
+			if qe = floorDiv(last+1, k); qe <= qb {
+				continue
+			}
+			qe--
+		}
+		if qb <= qe {
+			retval = append(retval, expandBlocks(qb, qe, k))
+		}
+	}
+	*s = retval
+	return nil
+}
+
+// expandBlocks returns the range of elements in the blocks qb to qe (inclusive), which must
+// have valid elements, ignoring elements outside the range of the element type
+func expandBlocks[T Element](qb, qe, k T) Span[T] {
+	retval := Span[T]{minInt[T](), minInt[T]()} // end-mark
+	if qb != floorDiv(minInt[T](), k) {
+		retval.Bot = qb * k // can't overflow since block qb-1 has valid elements
+	}
+	if qe != floorDiv(maxInt[T](), k) {
+		retval.Top = (qe + 1) * k // can't overflow since block qe+1 has valid elements
+	}
+	return retval
+}
@@ -0,0 +1,131 @@
+package rangeset_test
+
+import (
+	"github.com/andrewwphillips/rangeset"
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+type QuantizeElementType int
+
+// quantizeData is for table-driven tests of Quotient, Expand, AlignOut and AlignIn
+var quantizeData = map[string]struct {
+	in                                  string
+	k                                   QuantizeElementType
+	quotient, expand, alignOut, alignIn string
+}{
+	"Empty":     {"{}", 4, "{}", "{}", "{}", "{}"},
+	"One":       {"{1:5,7}", 1, "{1:5,7}", "{1:5,7}", "{1:5,7}", "{1:5,7}"},
+	"Pages":     {"{0:4095,8192:8200}", 4096, "{0,2}", "{0:16777215,33554432:33591295}", "{0:4095,8192:12287}", "{0:4095}"},
+	"Join":      {"{1,3,6}", 4, "{0:1}", "{4:7,12:15,24:27}", "{0:7}", "{}"},
+	"Negative":  {"{-9:-1,3}", 4, "{-3:0}", "{-36:-1,12:15}", "{-12:3}", "{-8:-1}"},
+	"Universal": {"{U}", 10, "{-922337203685477581:922337203685477580}", "{U}", "{U}", "{U}"},
+	"Ends":      {"{-9223372036854775808:-100,100:E}", 1000, "{-9223372036854776:-1,0:9223372036854775}", "{-9223372036854775808:-99001,100000:E}", "{U}", "{-9223372036854775808:-1001,1000:E}"},
+}
+
+// TestQuantizeTable tests Quotient, Expand, AlignOut and AlignIn using the quantizeData table
+func TestQuantizeTable(t *testing.T) {
+	for name, data := range quantizeData {
+		in, err := rangeset.NewFromString[QuantizeElementType](data.in)
+		Assertf(t, err == nil, "Quantize: %12s: error %v decoding %q", name, err, data.in)
+		for op, expectedStr := range map[string]string{"Quotient": data.quotient, "Expand": data.expand,
+			"AlignOut": data.alignOut, "AlignIn": data.alignIn} {
+			expected, err := rangeset.NewFromString[QuantizeElementType](expectedStr)
+			Assertf(t, err == nil, "Quantize: %12s: error %v decoding %q", name, err, expectedStr)
+			got := in.Copy()
+			switch op {
+			case "Quotient":
+				err = got.Quotient(data.k)
+			case "Expand":
+				err = got.Expand(data.k)
+			case "AlignOut":
+				err = got.AlignOut(data.k)
+			case "AlignIn":
+				err = got.AlignIn(data.k)
+			}
+			Assertf(t, err == nil, "%s: %12s: %v k=%d unexpected error %v", op, name, in, data.k, err)
+			Assertf(t, rangeset.Equal(got, expected), "%s: %12s: %v k=%d expected %v got %v", op, name, in, data.k, expected, got)
+		}
+	}
+}
+
+// TestQuantizeInvalid checks that a block size that is not positive returns ErrInvalidRange
+// (without modifying the set)
+func TestQuantizeInvalid(t *testing.T) {
+	in := rangeset.Make[QuantizeElementType](1, 2, 3, 10)
+	for _, k := range []QuantizeElementType{0, -1, math.MinInt} {
+		for op, f := range map[string]func(*rangeset.Set[QuantizeElementType], QuantizeElementType) error{
+			"Quotient": (*rangeset.Set[QuantizeElementType]).Quotient, "Expand": (*rangeset.Set[QuantizeElementType]).Expand,
+			"AlignOut": (*rangeset.Set[QuantizeElementType]).AlignOut, "AlignIn": (*rangeset.Set[QuantizeElementType]).AlignIn} {
+			got := in.Copy()
+			err := f(&got, k)
+			Assertf(t, err == rangeset.ErrInvalidRange, "QuantizeInvalid: %s k=%d expected ErrInvalidRange got %v", op, k, err)
+			Assertf(t, rangeset.Equal(got, in), "QuantizeInvalid: %s k=%d set modified to %v", op, k, got)
+		}
+	}
+}
+
+// floorDiv8 returns e/k rounded down using int arithmetic
+func floorDiv8(e, k int) int {
+	q := e / k
+	if e%k != 0 && e < 0 {
+		q--
+	}
+	return q
+}
+
+// testQuantizeBruteForce checks Quotient, Expand, AlignOut and AlignIn against brute force calculations
+func testQuantizeBruteForce[T int8 | uint8](t *testing.T, name string) {
+	r := rand.New(rand.NewPCG(45, 46))
+	for range 100 {
+		s := randomSet[T](r)
+		k := r.IntN(10) + 1
+		if r.IntN(10) == 0 {
+			k = r.IntN(127) + 1
+		}
+		// blockIn returns true if all (valid) elements of block q are in s
+		blockIn := func(q int) bool {
+			for e := q * k; e < (q+1)*k; e++ {
+				if int(T(e)) == e && !s.Contains(T(e)) {
+					return false
+				}
+			}
+			return true
+		}
+
+		quotient := bruteForce(func(q T) bool {
+			for e := int(q) * k; e < (int(q)+1)*k; e++ {
+				if int(T(e)) == e && s.Contains(T(e)) {
+					return true
+				}
+			}
+			return false
+		})
+		expand := bruteForce(func(e T) bool { return s.Contains(T(floorDiv8(int(e), k))) })
+		alignOut := bruteForce(func(e T) bool { return quotient.Contains(T(floorDiv8(int(e), k))) })
+		alignIn := bruteForce(func(e T) bool { return blockIn(floorDiv8(int(e), k)) })
+
+		for op, expected := range map[string]rangeset.Set[T]{"Quotient": quotient, "Expand": expand,
+			"AlignOut": alignOut, "AlignIn": alignIn} {
+			got := s.Copy()
+			switch op {
+			case "Quotient":
+				got.Quotient(T(k))
+			case "Expand":
+				got.Expand(T(k))
+			case "AlignOut":
+				got.AlignOut(T(k))
+			case "AlignIn":
+				got.AlignIn(T(k))
+			}
+			Assertf(t, rangeset.Equal(got, expected), "%s %s: %v k=%d expected %v got %v", name, op, s, k, expected, got)
+		}
+	}
+}
+
+// TestQuantizeBruteForce tests the block quantization methods using 8-bit elements
+func TestQuantizeBruteForce(t *testing.T) {
+	testQuantizeBruteForce[int8](t, "int8")
+	testQuantizeBruteForce[uint8](t, "uint8")
+}
@@ -0,0 +1,7 @@
+//go:build race
+
+package rangeset_test
+
+// raceEnabled is true when the tests are run with the race detector, which allocates memory
+// for closures that would otherwise not escape (so allocation counts can't be checked)
+const raceEnabled = true
@@ -0,0 +1,103 @@
+package rangeset
+
+// random.go implements methods that choose random elements (or ranges) of a set.  These use
+// the math/rand/v2 package - if the *rand.Rand parameter is nil the top-level functions of the
+// package are used.  See also the Indexed type which implements these more efficiently.
+
+import (
+	"math/rand/v2"
+)
+
+// RandomElement returns an element of the set chosen at random (all elements being equally
+// likely) or false if the set is empty.
+// It has time complexity O(r) where r is the number of ranges.
+func (s Set[T]) RandomElement(r *rand.Rand) (T, bool) {
+	n := s.total()
+	if n.isZero() {
+		return 0, false
+	}
+	return s.Select(randomPos(r, n))
+}
+
+// RandomSpan returns a range of the set chosen at random, or false if the set is empty.  The
+// chance of a range being chosen is proportional to the number of elements in it.
+// It has time complexity O(r) where r is the number of ranges.
+func (s Set[T]) RandomSpan(r *rand.Rand) (Span[T], bool) {
+	n := s.total()
+	if n.isZero() {
+		return Span[T]{}, false
+	}
+	pos := randomPos(r, n)
+	for _, v := range s[:len(s)-1] {
+		n := rangeLen(v.Bot, v.Top-1)
+		if n == 0 || pos < n { // n == 0 means 2^64 elements
+			return v, true
+		}
+		pos -= n
+	}
+	return s[len(s)-1], true // pos must be in the last range
+}
+
+// Sample returns k elements of the set chosen at random, in random order.  If replace is true
+// the same element may be chosen more than once, otherwise all the elements are different (in
+// which case fewer than k elements are returned if the set does not have k elements).  If k is
+// zero or negative (or the set is empty) it returns nil.  The result (and without replacement
+// the record of which elements have been chosen) uses memory proportional to k, or to the
+// number of elements of the set if that is smaller and replace is false.
+// Each call builds a temporary index of the set, so it has time complexity O(r + k log r)
+// where r is the number of ranges - to take many samples of the same set use an Indexed set.
+func (s Set[T]) Sample(r *rand.Rand, k int, replace bool) []T {
+	if k <= 0 || len(s) == 0 {
+		return nil // avoid building the index
+	}
+	ix := &Indexed[T]{set: s} // s is not modified so does not need to be copied
+	ix.reindex(0)
+	return ix.Sample(r, k, replace)
+}
+
+// randomPos returns a random position in a set, from 0 to n-1, where n is the number of elements
+func randomPos(r *rand.Rand, n count) uint64 {
+	switch {
+	case n.hi > 0 && r == nil:
+		return rand.Uint64() // all 2^64 elements of a 64-bit type
+	case n.hi > 0:
+		return r.Uint64()
+	case r == nil:
+		return rand.Uint64N(n.lo)
+	}
+	return r.Uint64N(n.lo)
+}
+
+// sample returns the positions of k different elements of a set of n elements in random order.
+// It uses Robert Floyd's algorithm so has time and space complexity O(k) however big n is.
+func sample(r *rand.Rand, n count, k int) []uint64 {
+	if n.hi == 0 && uint64(k) > n.lo {
+		k = int(n.lo) // can't choose more elements than there are
+	}
+	if k <= 0 {
+		return nil
+	}
+	retval := make([]uint64, 0, k)
+	chosen := make(map[uint64]struct{}, k)
+	last := n.sub(count{0, 1}).lo // last position (n-1 always fits in a uint64)
+	for j := last - uint64(k-1); ; j++ {
+		// Choose from positions 0 to j, using j if the chosen position is already taken
+		pos := randomPos(r, count{0, j}.plus(1))
+		if _, ok := chosen[pos]; ok {
+			pos = j
+		}
+		chosen[pos] = struct{}{}
+		retval = append(retval, pos)
+		if j == last {
+			break
+		}
+	}
+	// Floyd's algorithm chooses the elements fairly but not their order
+	swap := func(i, j int) { retval[i], retval[j] = retval[j], retval[i] }
+	if r == nil {
+		rand.Shuffle(len(retval), swap)
+	} else {
+		r.Shuffle(len(retval), swap)
+	}
+	return retval
+}
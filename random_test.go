@@ -0,0 +1,128 @@
+package rangeset_test
+
+import (
+	"github.com/andrewwphillips/rangeset"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+type RandomElementType int
+
+// randomData is for table-driven tests of RandomElement, RandomSpan and Sample
+var randomData = map[string]struct {
+	in     string
+	length int
+}{
+	"Empty":    {"{}", 0},
+	"One":      {"{1}", 1},
+	"Range":    {"{1:10}", 10},
+	"Ranges":   {"{-5:-3,0,7:12}", 10},
+	"Negative": {"{-100:-90}", 11},
+}
+
+// TestRandomTable checks that randomly chosen elements and ranges are in the set
+func TestRandomTable(t *testing.T) {
+	r := rand.New(rand.NewPCG(27, 28))
+	for name, data := range randomData {
+		s, _ := rangeset.NewFromString[RandomElementType](data.in)
+		ix := rangeset.NewIndexed(s)
+
+		e, ok := s.RandomElement(r)
+		Assertf(t, ok == (data.length > 0), "RandomElement: %12s: expected ok %t got %t", name, data.length > 0, ok)
+		Assertf(t, !ok || s.Contains(e), "RandomElement: %12s: %d is not in the set", name, e)
+		e, ok = ix.RandomElement(nil) // use the global generator
+		Assertf(t, ok == (data.length > 0), "Indexed.RandomElement: %12s: expected ok %t got %t", name, data.length > 0, ok)
+		Assertf(t, !ok || s.Contains(e), "Indexed.RandomElement: %12s: %d is not in the set", name, e)
+
+		v, ok := s.RandomSpan(r)
+		Assertf(t, ok == (data.length > 0), "RandomSpan: %12s: expected ok %t got %t", name, data.length > 0, ok)
+		Assertf(t, !ok || slices.Contains(s, v), "RandomSpan: %12s: %v is not a range of the set", name, v)
+
+		for _, k := range []int{-1, 0, 1, 5, data.length, data.length + 5} {
+			got := s.Sample(r, k, false)
+			slices.Sort(got)
+			expected := max(min(k, data.length), 0)
+			Assertf(t, len(got) == expected, "Sample: %12s: k=%d expected %d elements got %v", name, k, expected, got)
+			Assertf(t, len(slices.Compact(got)) == len(got), "Sample: %12s: k=%d elements not different %v", name, k, got)
+			Assertf(t, rangeset.Make(got...).IsSubsetOf(s), "Sample: %12s: k=%d elements not in set %v", name, k, got)
+
+			got = s.Sample(nil, k, true)
+			expected = max(k, 0)
+			if data.length == 0 {
+				expected = 0
+			}
+			Assertf(t, len(got) == expected, "Sample(replace): %12s: k=%d expected %d elements got %v", name, k, expected, got)
+			Assertf(t, rangeset.Make(got...).IsSubsetOf(s), "Sample(replace): %12s: k=%d elements not in set %v", name, k, got)
+		}
+	}
+}
+
+// TestRandomUniform checks that elements are chosen uniformly (not per range) and that
+// ranges are weighted by their length
+func TestRandomUniform(t *testing.T) {
+	const draws = 11000
+	s := rangeset.Make[RandomElementType](0) // 1 of 11 elements is in the first range
+	s.AddRange(10, 20)
+	ix := rangeset.NewIndexed(s)
+	r := rand.New(rand.NewPCG(29, 30))
+
+	var elements, indexed, spans int
+	for range draws {
+		if e, _ := s.RandomElement(r); e == 0 {
+			elements++
+		}
+		if e, _ := ix.RandomElement(r); e == 0 {
+			indexed++
+		}
+		if v, _ := s.RandomSpan(r); v.Bot == 0 {
+			spans++
+		}
+	}
+	for name, got := range map[string]int{"RandomElement": elements, "Indexed.RandomElement": indexed, "RandomSpan": spans} {
+		Assertf(t, got > 800 && got < 1200, "RandomUniform: %s: expected about %d of %d draws got %d", name, draws/11, draws, got)
+	}
+}
+
+// TestRandomIndexed checks that an Indexed set makes the same choices as a normal set
+func TestRandomIndexed(t *testing.T) {
+	r1, r2 := rand.New(rand.NewPCG(31, 32)), rand.New(rand.NewPCG(31, 32))
+	for range 50 {
+		s := randomSet[int8](r1)
+		randomSet[int8](r2) // keep the generators in step
+		ix := rangeset.NewIndexed(s)
+
+		e1, ok1 := s.RandomElement(r1)
+		e2, ok2 := ix.RandomElement(r2)
+		Assertf(t, e1 == e2 && ok1 == ok2, "RandomIndexed: %v: RandomElement expected %d,%t got %d,%t", s, e1, ok1, e2, ok2)
+		v1, ok1 := s.RandomSpan(r1)
+		v2, ok2 := ix.RandomSpan(r2)
+		Assertf(t, v1 == v2 && ok1 == ok2, "RandomIndexed: %v: RandomSpan expected %v,%t got %v,%t", s, v1, ok1, v2, ok2)
+		got1, got2 := s.Sample(r1, 10, false), ix.Sample(r2, 10, false)
+		Assertf(t, slices.Equal(got1, got2), "RandomIndexed: %v: Sample expected %v got %v", s, got1, got2)
+		got1, got2 = s.Sample(r1, -5, true), ix.Sample(r2, -5, true)
+		Assertf(t, got1 == nil && got2 == nil, "RandomIndexed: %v: Sample with negative k expected nil got %v and %v", s, got1, got2)
+	}
+}
+
+// TestRandom64 tests choosing elements of sets that have more elements than can be counted in a uint64
+func TestRandom64(t *testing.T) {
+	r := rand.New(rand.NewPCG(33, 34))
+	u := rangeset.Universal[uint64]()
+	_, ok := u.RandomElement(r)
+	Assertf(t, ok, "Random64: RandomElement of universal set failed")
+	v, ok := u.RandomSpan(r)
+	Assertf(t, ok && v == u[0], "Random64: RandomSpan expected %v,true got %v,%t", u[0], v, ok)
+
+	got := u.Sample(r, 100, false)
+	slices.Sort(got)
+	Assertf(t, len(got) == 100 && len(slices.Compact(got)) == 100, "Random64: Sample expected 100 different elements got %v", got)
+
+	s := rangeset.Universal[int64]()
+	s.Delete(0)
+	ix := rangeset.NewIndexed(s)
+	for range 100 {
+		e, ok := ix.RandomElement(r)
+		Assertf(t, ok && e != 0, "Random64: Indexed.RandomElement expected non-zero element got %d,%t", e, ok)
+	}
+}
@@ -0,0 +1,104 @@
+package rangeset
+
+// rank.go implements methods that treat the set as an ordered list of elements, such as
+// finding the position (rank) of an element or the element at a position (select).
+// See also the Indexed type which implements some of these more efficiently.
+
+import (
+	"math"
+	"math/big"
+)
+
+// Rank returns the number of elements of the set that are less than e.
+// (If e is in the set this is its position - eg the smallest element has a rank of 0).
+// It has time complexity O(r) where r is the number of ranges.
+func (s Set[T]) Rank(e T) (retval uint64) {
+	idx := s.bsearch(e)
+	for _, v := range s[:max(idx-1, 0)] {
+		retval += rangeLen(v.Bot, v.Top-1)
+	}
+	if idx > 0 {
+		retval += rankIn(s[idx-1], e)
+	}
+	return
+}
+
+// rankIn returns how many elements of span v are less than e, where e >= v.Bot
+func rankIn[T Element](v Span[T], e T) uint64 {
+	if v.Top-1 < e {
+		return rangeLen(v.Bot, v.Top-1) // all of v
+	}
+	return distance(v.Bot, e)
+}
+
+// Select returns the element at position k (starting at zero) in the set - ie the element with a
+// rank of k.  It returns false if there are not enough elements.
+// It has time complexity O(r) where r is the number of ranges.
+func (s Set[T]) Select(k uint64) (T, bool) {
+	for _, v := range s {
+		n := rangeLen(v.Bot, v.Top-1)
+		if n == 0 || k < n { // n == 0 means 2^64 elements
+			return v.Bot + T(k), true
+		}
+		k -= n
+	}
+	return 0, false
+}
+
+// CountRange returns the number of elements of the set in the range [b, t) (asymmetric bounds).
+// Like Length(), if the result is all the elements of a 64-bit type, 0 is returned.
+// It has time complexity O(log r + k) where k is the number of ranges that overlap [b, t).
+func (s Set[T]) CountRange(b, t T) uint64 {
+	length, _ := s.Coverage(b, t)
+	return length
+}
+
+// CountRangeBig is the same as CountRange but returns the exact number of elements
+func (s Set[T]) CountRangeBig(b, t T) *big.Int {
+	length, _ := s.CoverageBig(b, t)
+	return length
+}
+
+// Median returns the middle element of the set, or the lower of the middle two elements if
+// the set has an even number of elements.  It returns false if the set is empty.
+// It has time complexity O(r) where r is the number of ranges.
+func (s Set[T]) Median() (T, bool) {
+	n := s.total()
+	if n.isZero() {
+		return 0, false
+	}
+	return s.Select(medianIndex(n))
+}
+
+// Percentile returns the element at percentile p (0 to 100) of the set, using the nearest-rank
+// method - ie the smallest element such that at least p percent of the elements are less than
+// or equal to it.  It returns false if the set is empty or p is outside the range 0 to 100.
+// Note that the position is calculated using float64 so is approximate for very large sets.
+// It has time complexity O(r) where r is the number of ranges.
+func (s Set[T]) Percentile(p float64) (T, bool) {
+	k, ok := percentileIndex(s.total(), p)
+	if !ok {
+		return 0, false
+	}
+	return s.Select(k)
+}
+
+// medianIndex returns the position of the (lower) median of n (> 0) elements
+func medianIndex(n count) uint64 {
+	return n.sub(count{0, 1}).half().lo
+}
+
+// percentileIndex returns the position of the element at percentile p of n elements (nearest-rank)
+func percentileIndex(n count, p float64) (uint64, bool) {
+	if n.isZero() || !(p >= 0 && p <= 100) {
+		return 0, false
+	}
+	rank := math.Ceil(p / 100 * n.float64())
+	if rank < 1 {
+		return 0, true
+	}
+	if rank >= n.float64() {
+		return n.sub(count{0, 1}).lo, true // the largest element
+	}
+	return uint64(rank) - 1, true
+}
@@ -0,0 +1,141 @@
+package rangeset_test
+
+import (
+	"github.com/andrewwphillips/rangeset"
+	"math/rand/v2"
+	"testing"
+)
+
+type RankElementType int
+
+// rankData is for table-driven tests of Rank, Select, Median and Percentile
+var rankData = map[string]struct {
+	in                string
+	median, p25, p100 RankElementType
+	rank5             uint64
+	countRange3to7    uint64
+}{
+	"One":      {"{5}", 5, 5, 5, 0, 1},
+	"Two":      {"{1,9}", 1, 1, 9, 1, 0},
+	"Three":    {"{1,5,9}", 5, 1, 9, 1, 1},
+	"Range":    {"{1:10}", 5, 3, 10, 4, 4},
+	"Ranges":   {"{1:2,4:5,7:10}", 5, 2, 10, 3, 2},
+	"Negative": {"{-10:-1,8:9}", -5, -8, 9, 10, 0},
+}
+
+// TestRankTable tests Rank, Select, CountRange, Median and Percentile using the rankData table
+func TestRankTable(t *testing.T) {
+	for name, data := range rankData {
+		s, _ := rangeset.NewFromString[RankElementType](data.in)
+		ix := rangeset.NewIndexed(s)
+
+		got, ok := s.Median()
+		Assertf(t, ok && got == data.median, "Median: %12s: expected %d got %d (%t)", name, data.median, got, ok)
+		got, ok = ix.Median()
+		Assertf(t, ok && got == data.median, "Indexed Median: %12s: expected %d got %d (%t)", name, data.median, got, ok)
+		got, ok = s.Percentile(25)
+		Assertf(t, ok && got == data.p25, "Percentile: %12s: expected %d got %d (%t)", name, data.p25, got, ok)
+		got, ok = ix.Percentile(25)
+		Assertf(t, ok && got == data.p25, "Indexed Percentile: %12s: expected %d got %d (%t)", name, data.p25, got, ok)
+		got, ok = s.Percentile(100)
+		Assertf(t, ok && got == data.p100, "Percentile: %12s: expected %d got %d (%t)", name, data.p100, got, ok)
+		_, ok = s.Percentile(101)
+		Assertf(t, !ok, "Percentile: %12s: expected no result for 101 percent", name)
+
+		rank := s.Rank(5)
+		Assertf(t, rank == data.rank5, "Rank: %12s: expected %d got %d", name, data.rank5, rank)
+		rank = ix.Rank(5)
+		Assertf(t, rank == data.rank5, "Indexed Rank: %12s: expected %d got %d", name, data.rank5, rank)
+		count := s.CountRange(3, 7)
+		Assertf(t, count == data.countRange3to7, "CountRange: %12s: expected %d got %d", name, data.countRange3to7, count)
+		count = ix.CountRange(3, 7)
+		Assertf(t, count == data.countRange3to7, "Indexed CountRange: %12s: expected %d got %d", name, data.countRange3to7, count)
+	}
+}
+
+// TestRankEmpty checks the methods on an empty set
+func TestRankEmpty(t *testing.T) {
+	var s rangeset.Set[RankElementType]
+	var ix rangeset.Indexed[RankElementType]
+	_, ok := s.Median()
+	Assertf(t, !ok, "RankEmpty: expected no median")
+	_, ok = ix.Median()
+	Assertf(t, !ok, "RankEmpty: expected no indexed median")
+	_, ok = s.Select(0)
+	Assertf(t, !ok, "RankEmpty: expected no element at position 0")
+	_, ok = ix.Percentile(50)
+	Assertf(t, !ok, "RankEmpty: expected no indexed percentile")
+	Assertf(t, s.Rank(42) == 0 && ix.Rank(42) == 0, "RankEmpty: expected rank of 0")
+	Assertf(t, ix.Len() == 0, "RankEmpty: expected indexed length of 0 got %d", ix.Len())
+}
+
+// TestRank64 tests the methods on 64-bit sets with 2^64 elements
+func TestRank64(t *testing.T) {
+	u := rangeset.Universal[int64]()
+	ix := rangeset.NewIndexed(u)
+	Assertf(t, ix.Len() == -1, "Rank64: expected indexed Len of -1 got %d", ix.Len())
+	got, ok := u.Median()
+	Assertf(t, ok && got == -1, "Rank64: expected median of -1 got %d (%t)", got, ok)
+	got, ok = ix.Median()
+	Assertf(t, ok && got == -1, "Rank64: expected indexed median of -1 got %d (%t)", got, ok)
+	got, ok = u.Percentile(100)
+	Assertf(t, ok && got == 1<<63-1, "Rank64: expected 100th percentile of max got %d (%t)", got, ok)
+	got, ok = ix.Select(1<<64 - 1)
+	Assertf(t, ok && got == 1<<63-1, "Rank64: expected last element to be max got %d (%t)", got, ok)
+	rank := ix.Rank(0)
+	Assertf(t, rank == 1<<63, "Rank64: expected rank of zero to be %d got %d", uint64(1<<63), rank)
+	count := ix.CountRange(0, -1<<63) // end-mark
+	Assertf(t, count == 1<<63, "Rank64: expected %d non-negative elements got %d", uint64(1<<63), count)
+}
+
+// testRankBruteForce tests Rank, Select, CountRange and the Indexed type against brute force
+func testRankBruteForce[T int8 | uint8](t *testing.T, name string) {
+	endMark := rangeset.Universal[T]()[0].Top
+	r := rand.New(rand.NewPCG(13, 14))
+	for range 200 {
+		s := randomSet[T](r)
+		ix := rangeset.NewIndexed(s)
+		elts := elements(s)
+
+		for k := range len(elts) + 1 {
+			got, ok := s.Select(uint64(k))
+			got2, ok2 := ix.Select(uint64(k))
+			if k == len(elts) {
+				Assertf(t, !ok && !ok2, "%s Select: %v: expected no element at %d", name, s, k)
+				continue
+			}
+			Assertf(t, ok && got == elts[k], "%s Select: %v: expected %d at %d got %d", name, s, elts[k], k, got)
+			Assertf(t, ok2 && got2 == elts[k], "%s Indexed Select: %v: expected %d at %d got %d", name, s, elts[k], k, got2)
+			Assertf(t, s.Rank(elts[k]) == uint64(k), "%s Rank: %v: expected %d for %d got %d", name, s, k, elts[k], s.Rank(elts[k]))
+			Assertf(t, ix.Rank(elts[k]) == uint64(k), "%s Indexed Rank: %v: expected %d got %d", name, s, k, ix.Rank(elts[k]))
+		}
+		if len(elts) > 0 {
+			got, _ := s.Median()
+			Assertf(t, got == elts[(len(elts)-1)/2], "%s Median: %v: expected %d got %d", name, s, elts[(len(elts)-1)/2], got)
+		}
+
+		// Random ranges (including to the end-mark)
+		for range 10 {
+			b, top := T(r.IntN(256)), T(r.IntN(256))
+			if r.IntN(4) == 0 {
+				top = endMark
+			}
+			expected := uint64(0)
+			for _, e := range elts {
+				if e >= b && (e < top || top == endMark) && (top > b || top == endMark) {
+					expected++
+				}
+			}
+			got := s.CountRange(b, top)
+			Assertf(t, got == expected, "%s CountRange: %v [%d,%d): expected %d got %d", name, s, b, top, expected, got)
+			got = ix.CountRange(b, top)
+			Assertf(t, got == expected, "%s Indexed CountRange: %v [%d,%d): expected %d got %d", name, s, b, top, expected, got)
+		}
+	}
+}
+
+// TestRankBruteForce tests rank related methods against brute force calculations (using 8-bit elements)
+func TestRankBruteForce(t *testing.T) {
+	testRankBruteForce[int8](t, "int8")
+	testRankBruteForce[uint8](t, "uint8")
+}
@@ -4,11 +4,12 @@ package rangeset
 
 import (
 	"fmt"
+	"iter"
 	"strings"
 )
 
 // NewFromString deserializes a string (eg from format created by the String() method below).
-// If the format of the string is invalid it returns an error.
+// If the format of the string is invalid it returns a *ParseError.
 // Also note that it will accept more variations in strings than generated by the String()
 // method (below) (eg "{1,1}" which has a redundant element) but must always consist of zero
 // or more comma-separated integers or integer ranges (where a range is two integers separated by
@@ -22,8 +23,9 @@ import (
 func NewFromString[T Element](s string) (_ Set[T], _ error) {
 	s = strings.TrimSpace(s)
 	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
-		return nil, fmt.Errorf("rangeset: string %q for new set is not enclosed in braces", s)
+		return nil, &ParseError{Input: s, Msg: "string is not enclosed in braces"}
 	}
+	input := s
 	s = s[1 : len(s)-1]
 	if len(s) == 0 { // we need to handle this specially as strings.Split("") returns a slice with one element
 		return nil, nil // return empty set
@@ -43,14 +45,14 @@ func NewFromString[T Element](s string) (_ Set[T], _ error) {
 			parts := strings.Split(r, ":")
 			if len(parts) != 2 {
 				//assert(len(parts) > 2)
-				return nil, fmt.Errorf("rangeset: too many parts in range %q for set {%s}", r, s)
+				return nil, &ParseError{Input: input, Msg: fmt.Sprintf("too many parts in range %q", r)}
 			}
 			if parts[0] == "E" {
 				b = endMark
 			} else {
 				b, err = parseInt[T](parts[0])
 				if err != nil {
-					return nil, fmt.Errorf("rangeset: %w for an integer at start of range for set {%s}", err, s)
+					return nil, &ParseError{Input: input, Msg: fmt.Sprintf("invalid start of range %q", r), Err: err}
 				}
 			}
 			if parts[1] == "E" {
@@ -58,18 +60,18 @@ func NewFromString[T Element](s string) (_ Set[T], _ error) {
 			} else {
 				t, err = parseInt[T](parts[1])
 				if err != nil {
-					return nil, fmt.Errorf("rangeset: %w for an integer at end of range for set {%s}", err, s)
+					return nil, &ParseError{Input: input, Msg: fmt.Sprintf("invalid end of range %q", r), Err: err}
 				}
 			}
 		} else {
 			b, err = parseInt[T](r)
 			if err != nil {
-				return nil, fmt.Errorf("rangeset: %w for an integer in set {%s}", err, s)
+				return nil, &ParseError{Input: input, Msg: fmt.Sprintf("invalid element %q", r), Err: err}
 			}
 			t = b
 		}
 		if b > t {
-			return nil, fmt.Errorf("rangeset: invalid range %q (end < start) for set {%s}", r, s)
+			return nil, &ParseError{Input: input, Msg: fmt.Sprintf("end < start in range %q", r), Err: ErrInvalidRange}
 		}
 		// Note that this relies on integer overflow wrapping around if t is
 		// the maximum value for T (maxInt[T]()), whence t+1 wraps around to minInt[T]
@@ -82,11 +84,16 @@ func NewFromString[T Element](s string) (_ Set[T], _ error) {
 // Such a string can be "deserialised" using the above NewFromString() function.
 // TODO: add options to encode min/max values as "E" and universal set as U, add option to change sep. char (:)
 func (s Set[T]) String() string {
+	return spansString(len(s), s.SpansSeq())
+}
+
+// spansString serialises a sequence of (n) ordered ranges as for the String method (above)
+func spansString[T Element](n int, spans iter.Seq[Span[T]]) string {
 	var retval strings.Builder
-	retval.Grow(5 * len(s)) // est. of generated string length TODO: better estimate
+	retval.Grow(5 * n) // est. of generated string length TODO: better estimate
 	retval.WriteRune('{')
 	first := true
-	for _, r := range s {
+	for r := range spans {
 		if !first {
 			retval.WriteRune(',')
 		} else {
@@ -57,6 +57,8 @@ var stringErrorData = map[string]struct {
 	"RangeRandom":    {"{%89i:djsa.mdaja,esreiop}"},
 	"RangeComma":     {"{1;3:4}"},
 	"RangeColon":     {"{1,3-4}"},
+	"ValueOverflow":  {"{32768}"},
+	"RangeOverflow":  {"{-32769:0}"},
 }
 
 // TestStringError checks that malformed set strings cause an error in NewFromString
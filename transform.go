@@ -0,0 +1,168 @@
+package rangeset
+
+// transform.go implements methods that apply an arithmetic operation to every element of a
+// set, such as adding a value to every element.  Since the result of the operation may be
+// outside the range of the element type an Overflow policy says what should happen.
+
+// Overflow says what happens to elements of a set that are transformed (see Shift, Negate and
+// Scale) to a value that is out of the range of the element type
+type Overflow int
+
+const (
+	OverflowClip  Overflow = iota // elements that would overflow are removed from the set
+	OverflowWrap                  // elements wrap around as for normal integer arithmetic
+	OverflowError                 // the set is not modified and ErrOverflow is returned
+)
+
+// Shift adds delta to every element of the set.
+// It has time complexity O(r log r) where r is the number of ranges.
+func (s *Set[T]) Shift(delta int64, policy Overflow) error {
+	d := toInt128(delta)
+	return s.mapSpans(func(b, last T) (int128, int128) {
+		return toInt128(b).add(d), toInt128(last).add(d)
+	}, policy)
+}
+
+// Negate replaces every element of the set with its negative (ie mirrors the set around zero).
+// Note that for signed types the smallest element overflows, and for unsigned types all
+// elements (except zero) overflow.
+// It has time complexity O(r log r) where r is the number of ranges.
+func (s *Set[T]) Negate(policy Overflow) error {
+	return s.mapSpans(func(b, last T) (int128, int128) {
+		return toInt128(last).neg(), toInt128(b).neg()
+	}, policy)
+}
+
+// maxScaleLength is the largest number of elements (that are not clipped) a set can have to be
+// scaled by k, where k is more than one or less than minus one, as the result has a range for
+// every element (see Scale)
+const maxScaleLength = 1 << 24
+
+// Scale multiplies every element of the set by k.  If k is more than one (or less than minus
+// one) the elements of each range are spread out (eg {1:3} scaled by 2 is {2,4,6}), so the
+// result has a range for every element and the time complexity is O(n), or O(n log n) for
+// OverflowWrap since wrapped elements must be sorted.  Since the result would use a lot of
+// memory, if the set has more than 2^24 elements (that are not clipped) the set is not modified
+// and ErrTooLarge is returned.  This does not depend on the policy (though elements removed by
+// OverflowClip are not counted) - ErrOverflow is only returned for OverflowError when an element
+// is out of range.  (If k is zero the result is {0} - unless the set is empty.)
+func (s *Set[T]) Scale(k int64, policy Overflow) error {
+	switch k {
+	case 1:
+		return nil
+	case -1:
+		return s.Negate(policy)
+	case 0:
+		if len(*s) > 0 {
+			*s = append((*s)[:0], Span[T]{0, 1})
+		}
+		return nil
+	}
+
+	lo, hi := scaleBounds[T](k)
+	n := s.total()
+	switch policy {
+	case OverflowClip:
+		n, _ = s.coverage(lo, hi+1) // hi+1 wraps to the end-mark if hi is maxInt
+	case OverflowWrap:
+		lo, hi = minInt[T](), maxInt[T]() // all elements are scaled
+	default:
+		if l := len(*s); l > 0 && ((*s)[0].Bot < lo || (*s)[l-1].Top-1 > hi) {
+			return ErrOverflow
+		}
+	}
+	if (count{0, maxScaleLength}).less(n) {
+		return ErrTooLarge
+	}
+
+	retval := make(Set[T], 0, n.lo)
+	add := func(e T) bool {
+		retval = appendSpan(retval, e*T(k), e*T(k)) // wraps around (if it overflows) when the policy is OverflowWrap
+		return true
+	}
+	for idx := range *s {
+		v := (*s)[idx]
+		if k < 0 {
+			v = (*s)[len(*s)-1-idx] // the largest elements give the smallest results
+		}
+		b, last := max(v.Bot, lo), min(v.Top-1, hi)
+		if b > last {
+			continue // the whole range overflows (OverflowClip)
+		}
+		if v = (Span[T]{b, last + 1}); k < 0 {
+			v.walkBack(add)
+		} else {
+			v.walk(add)
+		}
+	}
+	if policy == OverflowWrap {
+		retval = normalize(retval)
+	}
+	*s = retval
+	return nil
+}
+
+// scaleBounds returns the smallest and largest elements that can be multiplied by k
+// (where k is not -1, 0 or 1) without overflow
+func scaleBounds[T Element](k int64) (lo, hi T) {
+	if isUnsigned[T]() {
+		if k < 0 {
+			return 0, 0
+		}
+		return 0, T(uint64(maxInt[T]()) / uint64(k))
+	}
+	// Note that integer division truncates towards zero
+	minElt, maxElt := int64(minInt[T]()), int64(maxInt[T]())
+	if k < 0 {
+		return T(maxElt / k), T(minElt / k)
+	}
+	return T(minElt / k), T(maxElt / k)
+}
+
+// mapSpans replaces every range [b, last] of the set with the range [lo, hi] returned by f,
+// where lo and hi may be outside the range of the element type (see Overflow)
+func (s *Set[T]) mapSpans(f func(b, last T) (lo, hi int128), policy Overflow) error {
+	spans := make([]Span[T], 0, len(*s)+1)
+	for _, v := range *s {
+		lo, hi := f(v.Bot, v.Top-1)
+		var err error
+		if spans, err = appendOverflow(spans, lo, hi, policy); err != nil {
+			return err
+		}
+	}
+	*s = normalize(spans)
+	return nil
+}
+
+// appendOverflow appends the range [lo, hi] (lo <= hi) to spans, where lo and hi may be outside
+// the range of the element type, in which case the Overflow policy determines what is appended.
+// The returned ranges are not in order so need to be normalized.
+func appendOverflow[T Element](spans []Span[T], lo, hi int128, policy Overflow) ([]Span[T], error) {
+	var endMark = minInt[T]() // in a range it flags: bottom/top of all valid elements
+	minElt, maxElt := toInt128(minInt[T]()), toInt128(maxInt[T]())
+	switch policy {
+	case OverflowClip:
+		if lo.less(minElt) {
+			lo = minElt
+		}
+		if maxElt.less(hi) {
+			hi = maxElt
+		}
+		if hi.less(lo) {
+			return spans, nil // the whole range overflows
+		}
+	case OverflowWrap:
+		if !hi.add(lo.neg()).less(maxElt.add(minElt.neg())) {
+			return append(spans, Span[T]{endMark, endMark}), nil // range has at least all the elements
+		}
+		if b, last := T(lo.lo), T(hi.lo); b > last {
+			// The range wraps around so split it in two
+			return append(spans, Span[T]{b, endMark}, Span[T]{endMark, last + 1}), nil
+		}
+	default:
+		if lo.less(minElt) || maxElt.less(hi) {
+			return spans, ErrOverflow
+		}
+	}
+	return append(spans, Span[T]{T(lo.lo), T(hi.lo) + 1}), nil // +1 wraps to the end-mark if hi is maxInt
+}
@@ -0,0 +1,189 @@
+package rangeset_test
+
+import (
+	"github.com/andrewwphillips/rangeset"
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+type TransformElementType int64
+
+// transformData is for table-driven tests of Shift, Negate and Scale
+var transformData = map[string]struct {
+	in                string
+	op                string // "Shift", "Negate" or "Scale"
+	k                 int64  // delta for Shift or multiplier for Scale
+	clip, wrap, error string // expected results for each policy ("" means ErrOverflow)
+}{
+	"ShiftEmpty":     {"{}", "Shift", 10, "{}", "{}", "{}"},
+	"ShiftZero":      {"{1:5}", "Shift", 0, "{1:5}", "{1:5}", "{1:5}"},
+	"ShiftUp":        {"{1:5,8}", "Shift", 10, "{11:15,18}", "{11:15,18}", "{11:15,18}"},
+	"ShiftDown":      {"{1:5,8}", "Shift", -10, "{-9:-5,-2}", "{-9:-5,-2}", "{-9:-5,-2}"},
+	"ShiftToEnd":     {"{1,10:E}", "Shift", 5, "{6,15:E}", "{L:-9223372036854775804,6,15:E}", ""},
+	"ShiftFromStart": {"{L:10,20}", "Shift", -5, "{L:5,15}", "{L:5,15,9223372036854775803:E}", ""},
+	"ShiftUniversal": {"{U}", "Shift", 100, "{-9223372036854775708:E}", "{U}", ""},
+	"ShiftMax":       {"{-1:1}", "Shift", math.MaxInt64, "{9223372036854775806:E}", "{9223372036854775806:E,L}", ""},
+	"NegateEmpty":    {"{}", "Negate", 0, "{}", "{}", "{}"},
+	"Negate":         {"{-3:-1,4,10:20}", "Negate", 0, "{-20:-10,-4,1:3}", "{-20:-10,-4,1:3}", "{-20:-10,-4,1:3}"},
+	"NegateUniverse": {"{U}", "Negate", 0, "{-9223372036854775807:E}", "{U}", ""},
+	"NegateStart":    {"{L:-5}", "Negate", 0, "{5:E}", "{5:E,L}", ""},
+	"ScaleOne":       {"{1:5}", "Scale", 1, "{1:5}", "{1:5}", "{1:5}"},
+	"ScaleZero":      {"{1:5}", "Scale", 0, "{0}", "{0}", "{0}"},
+	"ScaleZeroEmpty": {"{}", "Scale", 0, "{}", "{}", "{}"},
+	"ScaleMinusOne":  {"{1:5}", "Scale", -1, "{-5:-1}", "{-5:-1}", "{-5:-1}"},
+	"ScaleTwo":       {"{-1:1,5}", "Scale", 2, "{-2,0,2,10}", "{-2,0,2,10}", "{-2,0,2,10}"},
+	"ScaleMinusTwo":  {"{-1:1,5}", "Scale", -2, "{-10,-2,0,2}", "{-10,-2,0,2}", "{-10,-2,0,2}"},
+	"ScaleBig":       {"{-1:1,4611686018427387904}", "Scale", 2, "{-2,0,2}", "{L,-2,0,2}", ""},
+}
+
+// TestTransformTable tests Shift, Negate and Scale using the transformData table
+func TestTransformTable(t *testing.T) {
+	for name, data := range transformData {
+		in, err := rangeset.NewFromString[TransformElementType](expandLimits(data.in))
+		Assertf(t, err == nil, "Transform: %16s: error %v decoding %q", name, err, data.in)
+		for policy, expectedStr := range map[rangeset.Overflow]string{rangeset.OverflowClip: data.clip,
+			rangeset.OverflowWrap: data.wrap, rangeset.OverflowError: data.error} {
+			got := in.Copy()
+			switch data.op {
+			case "Shift":
+				err = got.Shift(data.k, policy)
+			case "Negate":
+				err = got.Negate(policy)
+			case "Scale":
+				err = got.Scale(data.k, policy)
+			}
+			if expectedStr == "" {
+				Assertf(t, err == rangeset.ErrOverflow, "%s: %16s: policy %d: expected ErrOverflow got %v", data.op, name, policy, err)
+				Assertf(t, rangeset.Equal(got, in), "%s: %16s: policy %d: set modified to %v", data.op, name, policy, got)
+				continue
+			}
+			expected, _ := rangeset.NewFromString[TransformElementType](expandLimits(expectedStr))
+			Assertf(t, err == nil, "%s: %16s: policy %d: unexpected error %v", data.op, name, policy, err)
+			Assertf(t, rangeset.Equal(got, expected), "%s: %16s: policy %d: expected %v got %v", data.op, name, policy, expected, got)
+		}
+	}
+}
+
+// expandLimits replaces L in a set string with the smallest int64 (since String uses E for the largest)
+func expandLimits(s string) string {
+	var retval []byte
+	for idx := range len(s) {
+		if s[idx] == 'L' {
+			retval = append(retval, "-9223372036854775808"...)
+			continue
+		}
+		retval = append(retval, s[idx])
+	}
+	return string(retval)
+}
+
+// testTransformBruteForce checks Shift, Negate and Scale against transforming every element
+func testTransformBruteForce[T int8 | uint8](t *testing.T, name string) {
+	minElt, maxElt := int(rangeset.Universal[T]()[0].Bot), int(rangeset.Universal[T]()[0].Top-1)
+	r := rand.New(rand.NewPCG(43, 44))
+	for range 200 {
+		s := randomSet[T](r)
+		op := r.IntN(3)
+		k := int64(r.IntN(601) - 300) // delta for Shift
+		if op == 2 {
+			k = int64(r.IntN(11) - 5) // multiplier for Scale
+		}
+		f := func(e T) int {
+			switch op {
+			case 0:
+				return int(e) + int(k)
+			case 1:
+				return -int(e)
+			}
+			return int(e) * int(k)
+		}
+
+		for _, policy := range []rangeset.Overflow{rangeset.OverflowClip, rangeset.OverflowWrap, rangeset.OverflowError} {
+			var expected rangeset.Set[T]
+			overflow := false
+			for _, e := range elements(s) {
+				v := f(e)
+				if v < minElt || v > maxElt {
+					overflow = true
+					if policy == rangeset.OverflowClip {
+						continue
+					}
+				}
+				expected.Add(T(v)) // conversion wraps around
+			}
+			got := s.Copy()
+			var err error
+			switch op {
+			case 0:
+				err = got.Shift(k, policy)
+			case 1:
+				err = got.Negate(policy)
+			case 2:
+				err = got.Scale(k, policy)
+			}
+			if policy == rangeset.OverflowError && overflow {
+				Assertf(t, err == rangeset.ErrOverflow, "%s op %d: %v k=%d expected ErrOverflow got %v", name, op, s, k, err)
+				Assertf(t, rangeset.Equal(got, s), "%s op %d: %v k=%d set modified to %v", name, op, s, k, got)
+				continue
+			}
+			Assertf(t, err == nil, "%s op %d: %v k=%d policy %d unexpected error %v", name, op, s, k, policy, err)
+			Assertf(t, rangeset.Equal(got, expected), "%s op %d: %v k=%d policy %d expected %v got %v",
+				name, op, s, k, policy, expected, got)
+		}
+	}
+}
+
+// TestTransformBruteForce tests Shift, Negate and Scale using 8-bit elements
+func TestTransformBruteForce(t *testing.T) {
+	testTransformBruteForce[int8](t, "int8")
+	testTransformBruteForce[uint8](t, "uint8")
+}
+
+// TestTransformUnsigned tests transforms of sets with unsigned 64-bit elements
+func TestTransformUnsigned(t *testing.T) {
+	s := rangeset.Universal[uint64]()
+	err := s.Shift(-10, rangeset.OverflowClip)
+	expected := rangeset.NewFromRange[uint64](0, math.MaxUint64-9)
+	Assertf(t, err == nil && rangeset.Equal(s, expected), "TransformUnsigned: Shift expected %v got %v (%v)", expected, s, err)
+
+	err = s.Negate(rangeset.OverflowClip)
+	expected = rangeset.Make[uint64](0)
+	Assertf(t, err == nil && rangeset.Equal(s, expected), "TransformUnsigned: Negate expected %v got %v (%v)", expected, s, err)
+
+	s = rangeset.Set[uint64]{}
+	s.AddRange(math.MaxUint64/2-1, 0) // 0 is the end-mark
+	err = s.Scale(2, rangeset.OverflowClip)
+	expected = rangeset.Make[uint64](math.MaxUint64-3, math.MaxUint64-1)
+	Assertf(t, err == nil && rangeset.Equal(s, expected), "TransformUnsigned: Scale expected %v got %v (%v)", expected, s, err)
+}
+
+// TestScaleLarge checks that scaling a set with a huge number of elements returns ErrTooLarge
+// (rather than running out of memory) whatever the policy, unless most of the elements are clipped
+func TestScaleLarge(t *testing.T) {
+	for name, data := range map[string]struct {
+		in     rangeset.Set[uint64]
+		k      int64
+		policy rangeset.Overflow
+	}{
+		"Clip":      {rangeset.NewClosed[uint64](0, 1<<40), 2, rangeset.OverflowClip},
+		"Wrap":      {rangeset.Universal[uint64](), 3, rangeset.OverflowWrap},
+		"Error":     {rangeset.NewClosed[uint64](0, 1<<40), 2, rangeset.OverflowError},
+		"JustLarge": {rangeset.NewClosed[uint64](1, 1<<24+1), -2, rangeset.OverflowWrap},
+	} {
+		got := data.in.Copy()
+		err := got.Scale(data.k, data.policy)
+		Assertf(t, err == rangeset.ErrTooLarge, "ScaleLarge: %10s: expected ErrTooLarge got %v", name, err)
+		Assertf(t, rangeset.Equal(got, data.in), "ScaleLarge: %10s: set modified to %v", name, got)
+	}
+
+	// An element out of range is still reported as ErrOverflow
+	s := rangeset.Universal[uint64]()
+	err := s.Scale(2, rangeset.OverflowError)
+	Assertf(t, err == rangeset.ErrOverflow, "ScaleLarge: Error expected ErrOverflow got %v", err)
+
+	// Only 4 elements of the universal set do not overflow
+	err = s.Scale(1<<62, rangeset.OverflowClip)
+	expected := rangeset.Make[uint64](0, 1<<62, 1<<63, 3<<62)
+	Assertf(t, err == nil && rangeset.Equal(s, expected), "ScaleLarge: Clip expected %v got %v (%v)", expected, s, err)
+}
@@ -4,20 +4,45 @@ package rangeset
 //  Iterate and Filter methods - use a function to operate on the whole set
 //  Iterator and ReadAll - use channels of the element type
 //  Seq - returns an iterator (Go 1.23) over all elements of the set
+//  SeqFrom, SeqRange and Backward - iterators over some elements or in reverse order
 
 import (
 	"context"
 	"iter"
 )
 
+// walk calls yield on every element of the range v in order, returning false if yield returns
+// false (to stop early).  Note that a loop like "for e := v.Bot; e < v.Top; e++" does not work
+// for a range that extends to the largest element (where Top is the end-mark) but this does.
+func (v Span[T]) walk(yield func(T) bool) bool {
+	for e := v.Bot; ; e++ {
+		if !yield(e) {
+			return false
+		}
+		if e == v.Top-1 {
+			return true // last element (e++ would wrap around if e is the largest element)
+		}
+	}
+}
+
+// walkBack is like walk but calls yield on the elements of v in reverse order (largest first)
+func (v Span[T]) walkBack(yield func(T) bool) bool {
+	for e := v.Top - 1; ; e-- {
+		if !yield(e) {
+			return false
+		}
+		if e == v.Bot {
+			return true // first element (e-- would wrap around if e is the smallest element)
+		}
+	}
+}
+
 // Seq returns a Go 1.23 iterator of the set elements in order
 func (s Set[T]) Seq() iter.Seq[T] {
 	return func(yield func(T) bool) {
 		for _, v := range s {
-			for e := v.Bot; e < v.Top; e++ {
-				if !yield(e) {
-					return
-				}
+			if !v.walk(yield) {
+				return
 			}
 		}
 	}
@@ -34,28 +59,59 @@ func (s Set[T]) SpansSeq() iter.Seq[Span[T]] {
 	}
 }
 
+// SeqFrom returns a Go 1.23 iterator of the set elements (in order) starting at the first element
+// not less than e.  Earlier ranges are skipped using a binary search - O(log r).
+func (s Set[T]) SeqFrom(e T) iter.Seq[T] {
+	return s.Window(e, minInt[T]()).Seq()
+}
+
+// SeqRange returns a Go 1.23 iterator of the set elements (in order) within the range [b, t)
+func (s Set[T]) SeqRange(b, t T) iter.Seq[T] {
+	return s.Window(b, t).Seq()
+}
+
+// SpansSeqRange returns a Go 1.23 iterator of the ranges of the set within the range [b, t).
+// The first and last ranges are trimmed so that they do not extend outside of [b, t).
+func (s Set[T]) SpansSeqRange(b, t T) iter.Seq[Span[T]] {
+	return s.Window(b, t).SpansSeq()
+}
+
+// Backward returns a Go 1.23 iterator of the set elements in reverse order (largest first)
+func (s Set[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for idx := len(s) - 1; idx >= 0; idx-- {
+			if !s[idx].walkBack(yield) {
+				return
+			}
+		}
+	}
+}
+
+// SpansBackward returns a Go 1.23 iterator of the ranges of the set in reverse order
+func (s Set[T]) SpansBackward() iter.Seq[Span[T]] {
+	return func(yield func(Span[T]) bool) {
+		for idx := len(s) - 1; idx >= 0; idx-- {
+			if !yield(s[idx]) {
+				return
+			}
+		}
+	}
+}
+
 // Iterate calls f on every element in the set.
 func (s Set[T]) Iterate(f func(T)) {
 	for _, v := range s {
-		for e := v.Bot; e < v.Top; e++ {
+		v.walk(func(e T) bool {
 			f(e)
-		}
+			return true
+		})
 	}
 }
 
 // Filter deletes elements from s for which f returns false.
-// Time complexity is O(n)
-// TODO look at optimising (eg by bunching into whole ranges before adding to toDelete)
+// Time complexity is O(n).  It is the same as RetainFunc (see filter.go).
 func (s *Set[T]) Filter(f func(T) bool) {
-	toDelete := Make[T]()
-	for _, v := range *s {
-		for e := v.Bot; e < v.Top; e++ {
-			if !f(e) {
-				toDelete.Add(e) // keep track of elts to delete
-			}
-		}
-	}
-	s.SubSet(toDelete) // delete the elts
+	s.RetainFunc(f)
 }
 
 // Iterator returns a channel that receives the elements of the set in order.
@@ -69,12 +125,16 @@ func (s Set[T]) Iterator(ctx context.Context) <-chan T {
 	go func(ch chan<- T) {
 		defer close(ch)
 		for _, v := range s {
-			for e := v.Bot; e < v.Top; e++ {
+			more := v.walk(func(e T) bool {
 				select {
 				case <-ctx.Done():
-					return
+					return false
 				case ch <- e:
+					return true
 				}
+			})
+			if !more {
+				return
 			}
 		}
 	}(r)
@@ -4,6 +4,8 @@ import (
 	"context"
 	"github.com/andrewwphillips/rangeset"
 	"iter"
+	"math/rand/v2"
+	"slices"
 	"testing"
 )
 
@@ -203,3 +205,162 @@ func TestChanRoundTrip(t *testing.T) {
 		Assertf(t, rangeset.Equal(in, out), "ChanRoundTrip: %12s: expected %v, got %v", name, in, out)
 	}
 }
+
+// seqRangeData is for table-driven tests of SeqFrom, SeqRange and Backward
+var seqRangeData = map[string]struct {
+	in       string
+	b, t     traverseType
+	expected []traverseType // elements of in within [b, t)
+}{
+	"Empty":    {"{}", 1, 10, nil},
+	"Inside":   {"{1:20}", 5, 8, []traverseType{5, 6, 7}},
+	"Trimmed":  {"{1:3,5:7,9:11}", 2, 10, []traverseType{2, 3, 5, 6, 7, 9}},
+	"InGap":    {"{1:3,9:11}", 4, 9, nil},
+	"Before":   {"{1:3}", -5, 1, nil},
+	"After":    {"{1:3}", 4, 100, nil},
+	"Reversed": {"{1:20}", 10, 5, nil},
+}
+
+// TestSeqRange tests SeqRange, SeqFrom and Backward using the seqRangeData table
+func TestSeqRange(t *testing.T) {
+	for name, data := range seqRangeData {
+		in, _ := rangeset.NewFromString[traverseType](data.in)
+		got := slices.Collect(in.SeqRange(data.b, data.t))
+		Assertf(t, slices.Equal(got, data.expected), "SeqRange: %12s: expected %v, got %v", name, data.expected, got)
+
+		got = slices.Collect(in.SeqFrom(data.b))
+		expected := slices.DeleteFunc(in.Values(), func(e traverseType) bool { return e < data.b })
+		Assertf(t, slices.Equal(got, expected), "SeqFrom: %12s: expected %v, got %v", name, expected, got)
+	}
+}
+
+// testSeqRangeBruteForce checks the range-restricted and reverse iterators against brute force calculations
+func testSeqRangeBruteForce[T int8 | uint8](t *testing.T, name string) {
+	endMark := rangeset.Universal[T]()[0].Top
+	r := rand.New(rand.NewPCG(25, 26))
+	for range 300 {
+		s := randomSet[T](r)
+		b, top := T(r.IntN(256)), T(r.IntN(256))
+		if r.IntN(4) == 0 {
+			top = endMark
+		}
+		all := elements(s)
+		var expected []T
+		for _, e := range all {
+			if e >= b && (e < top || top == endMark) {
+				expected = append(expected, e)
+			}
+		}
+
+		got := slices.Collect(s.SeqRange(b, top))
+		Assertf(t, slices.Equal(got, expected), "%s SeqRange: %v [%d,%d) expected %v got %v", name, s, b, top, expected, got)
+
+		var fromSpans []T
+		for v := range s.SpansSeqRange(b, top) {
+			fromSpans = append(fromSpans, elements(rangeset.Set[T]{v})...)
+		}
+		Assertf(t, slices.Equal(fromSpans, expected), "%s SpansSeqRange: %v [%d,%d) expected %v got %v", name, s, b, top, expected, fromSpans)
+
+		got = slices.Collect(s.SeqFrom(b))
+		expected = slices.DeleteFunc(slices.Clone(all), func(e T) bool { return e < b })
+		Assertf(t, slices.Equal(got, expected), "%s SeqFrom: %v %d expected %v got %v", name, s, b, expected, got)
+
+		got = slices.Collect(s.Backward())
+		expected = slices.Clone(all)
+		slices.Reverse(expected)
+		Assertf(t, slices.Equal(got, expected), "%s Backward: %v expected %v got %v", name, s, expected, got)
+
+		spans := slices.Collect(s.SpansBackward())
+		slices.Reverse(spans)
+		Assertf(t, rangeset.Equal(rangeset.Set[T](spans), s), "%s SpansBackward: expected %v got %v", name, s, spans)
+	}
+}
+
+// TestSeqRangeBruteForce tests the range-restricted and reverse iterators using 8-bit elements
+func TestSeqRangeBruteForce(t *testing.T) {
+	testSeqRangeBruteForce[int8](t, "int8")
+	testSeqRangeBruteForce[uint8](t, "uint8")
+}
+
+// TestBackwardStop tests that a reverse iteration can be stopped early
+func TestBackwardStop(t *testing.T) {
+	in := rangeset.Make[traverseType](7, 42, 73, 86, 99)
+	var got []traverseType
+	for v := range in.Backward() {
+		if v < 73 {
+			break
+		}
+		got = append(got, v)
+	}
+	Assertf(t, slices.Equal(got, []traverseType{99, 86, 73}), "BackwardStop: expected [99 86 73], got %v", got)
+
+	var spans []rangeset.Span[traverseType]
+	for v := range in.SpansBackward() {
+		spans = append(spans, v)
+		break
+	}
+	Assertf(t, len(spans) == 1 && spans[0].Bot == 99, "BackwardStop: expected one span {99}, got %v", spans)
+}
+
+// testTraverseBruteForce checks that every element-level traversal of a set (including ranges that
+// extend to the largest element) sees the same elements as a brute force search of all elements
+func testTraverseBruteForce[T int8 | uint8](t *testing.T, name string) {
+	r := rand.New(rand.NewPCG(51, 52))
+	endMark := rangeset.Universal[T]()[0].Top
+	sets := []rangeset.Set[T]{rangeset.Universal[T](), {{endMark - 1, endMark}}, {{endMark, endMark + 3}, {endMark - 10, endMark}}}
+	for range 200 {
+		sets = append(sets, randomSet[T](r))
+	}
+	for _, s := range sets {
+		expected := elements(s) // all elements found by calling Contains on every possible value
+
+		got := slices.Collect(s.Seq())
+		Assertf(t, slices.Equal(got, expected), "%s TraverseBruteForce: Seq of %v expected %v got %v", name, s, expected, got)
+		got = s.Values()
+		Assertf(t, slices.Equal(got, expected), "%s TraverseBruteForce: Values of %v expected %v got %v", name, s, expected, got)
+		got = got[:0]
+		s.Iterate(func(e T) { got = append(got, e) })
+		Assertf(t, slices.Equal(got, expected), "%s TraverseBruteForce: Iterate of %v expected %v got %v", name, s, expected, got)
+		got = got[:0]
+		for e := range s.Iterator(context.Background()) {
+			got = append(got, e)
+		}
+		Assertf(t, slices.Equal(got, expected), "%s TraverseBruteForce: Iterator of %v expected %v got %v", name, s, expected, got)
+		got = slices.Collect(s.Backward())
+		slices.Reverse(got)
+		Assertf(t, slices.Equal(got, expected), "%s TraverseBruteForce: Backward of %v expected reverse of %v got %v", name, s, expected, got)
+
+		filtered := s.Copy()
+		filtered.Filter(func(e T) bool { return e%2 == 0 })
+		evens := bruteForce(func(e T) bool { return s.Contains(e) && e%2 == 0 })
+		Assertf(t, rangeset.Equal(filtered, evens), "%s TraverseBruteForce: Filter of %v expected %v got %v", name, s, evens, filtered)
+
+		// Stopping early should work even in the last range
+		if len(expected) > 0 {
+			last := expected[len(expected)-1]
+			var n int
+			for e := range s.Seq() {
+				n++
+				if e == last {
+					break
+				}
+			}
+			Assertf(t, n == len(expected), "%s TraverseBruteForce: Seq of %v stopped after %d elements, expected %d", name, s, n, len(expected))
+
+			n = 0
+			for e := range s.Backward() {
+				n++
+				if e == expected[0] {
+					break
+				}
+			}
+			Assertf(t, n == len(expected), "%s TraverseBruteForce: Backward of %v stopped after %d elements, expected %d", name, s, n, len(expected))
+		}
+	}
+}
+
+// TestTraverseBruteForce tests the element-level traversals using 8-bit elements
+func TestTraverseBruteForce(t *testing.T) {
+	testTraverseBruteForce[int8](t, "int8")
+	testTraverseBruteForce[uint8](t, "uint8")
+}
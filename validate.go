@@ -0,0 +1,37 @@
+package rangeset
+
+// validate.go implements methods to check and fix sets that were not created using the
+// functions and methods of the package - eg a set created from a composite literal or
+// decoded from JSON, which may have ranges that are out of order, overlapping, etc
+
+// Validate checks that the ranges of the set are valid, sorted and neither overlap nor are
+// adjacent (all the methods of the package assume this).  If not it returns a *ValidationError
+// with the index of the first invalid range.  It has time complexity O(r).
+func (s Set[T]) Validate() error {
+	var endMark = minInt[T]() // indicates top/bottom of range of valid elements
+	for idx, v := range s {
+		if v.Top <= v.Bot && v.Top != endMark {
+			return &ValidationError{Index: idx, Msg: "empty range", Err: ErrInvalidRange}
+		}
+		if idx == 0 {
+			continue
+		}
+		prev := s[idx-1]
+		switch {
+		case prev.Top == endMark || v.Bot < prev.Bot:
+			return &ValidationError{Index: idx, Msg: "range out of order", Err: ErrInvalidSet}
+		case v.Bot < prev.Top:
+			return &ValidationError{Index: idx, Msg: "range overlaps previous range", Err: ErrInvalidSet}
+		case v.Bot == prev.Top:
+			return &ValidationError{Index: idx, Msg: "range is adjacent to previous range", Err: ErrInvalidSet}
+		}
+	}
+	return nil
+}
+
+// Normalize turns an invalid set (see Validate) into a valid one by sorting the ranges,
+// merging those that overlap or are adjacent, and removing empty ones.  It reuses the
+// memory of the set and has time complexity O(r log r).
+func (s *Set[T]) Normalize() {
+	*s = normalize(*s)
+}
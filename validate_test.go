@@ -0,0 +1,96 @@
+package rangeset_test
+
+import (
+	"errors"
+	"github.com/andrewwphillips/rangeset"
+	"math/rand/v2"
+	"testing"
+)
+
+type ValidateElementType int8
+
+var validateData = map[string]struct {
+	in       rangeset.Set[ValidateElementType]
+	index    int   // index of first invalid range (-1 if valid)
+	expected error // sentinel error wrapped by the ValidationError
+	norm     string
+}{
+	"Nil":          {nil, -1, nil, "{}"},
+	"Empty":        {rangeset.Set[ValidateElementType]{}, -1, nil, "{}"},
+	"One":          {rangeset.Set[ValidateElementType]{{1, 2}}, -1, nil, "{1}"},
+	"Two":          {rangeset.Set[ValidateElementType]{{1, 3}, {5, 8}}, -1, nil, "{1:2,5:7}"},
+	"Universal":    {rangeset.Set[ValidateElementType]{{-128, -128}}, -1, nil, "{-128:127}"},
+	"ToEnd":        {rangeset.Set[ValidateElementType]{{1, 3}, {100, -128}}, -1, nil, "{1:2,100:127}"},
+	"EmptyRange":   {rangeset.Set[ValidateElementType]{{1, 3}, {5, 5}}, 1, rangeset.ErrInvalidRange, "{1:2}"},
+	"Reversed":     {rangeset.Set[ValidateElementType]{{3, 1}}, 0, rangeset.ErrInvalidRange, "{}"},
+	"Unsorted":     {rangeset.Set[ValidateElementType]{{5, 8}, {1, 3}}, 1, rangeset.ErrInvalidSet, "{1:2,5:7}"},
+	"Overlap":      {rangeset.Set[ValidateElementType]{{1, 6}, {5, 8}}, 1, rangeset.ErrInvalidSet, "{1:7}"},
+	"Adjacent":     {rangeset.Set[ValidateElementType]{{1, 5}, {5, 8}}, 1, rangeset.ErrInvalidSet, "{1:7}"},
+	"Duplicate":    {rangeset.Set[ValidateElementType]{{1, 5}, {1, 5}}, 1, rangeset.ErrInvalidSet, "{1:4}"},
+	"AfterEnd":     {rangeset.Set[ValidateElementType]{{100, -128}, {120, 125}}, 1, rangeset.ErrInvalidSet, "{100:127}"},
+	"AfterUniv":    {rangeset.Set[ValidateElementType]{{-128, -128}, {0, 1}}, 1, rangeset.ErrInvalidSet, "{-128:127}"},
+	"LastAdjacent": {rangeset.Set[ValidateElementType]{{1, 3}, {5, 7}, {7, -128}}, 2, rangeset.ErrInvalidSet, "{1:2,5:127}"},
+}
+
+// TestValidate checks that Validate finds the first invalid range and that Normalize fixes it
+func TestValidate(t *testing.T) {
+	for name, data := range validateData {
+		err := data.in.Validate()
+		var ve *rangeset.ValidationError
+		if data.index < 0 {
+			Assertf(t, err == nil, "Validate: %12s: expected no error got %v", name, err)
+		} else {
+			Assertf(t, errors.As(err, &ve), "Validate: %12s: expected *ValidationError got %v", name, err)
+			if ve == nil {
+				continue
+			}
+			Assertf(t, ve.Index == data.index, "Validate: %12s: expected index %d got %d", name, data.index, ve.Index)
+			Assertf(t, errors.Is(err, data.expected), "Validate: %12s: expected %v got %v", name, data.expected, err)
+		}
+
+		got := rangeset.NewFromSpans(data.in...)
+		Assertf(t, got.String() == data.norm, "NewFromSpans: %12s: expected %q got %q", name, data.norm, got.String())
+
+		s := data.in.Copy()
+		s.Normalize()
+		Assertf(t, s.String() == data.norm, "Normalize: %12s: expected %q got %q", name, data.norm, s.String())
+		Assertf(t, s.Validate() == nil, "Normalize: %12s: expected valid set got %v", name, s.Validate())
+	}
+}
+
+// testValidateBruteForce checks Validate and NewFromSpans using random (usually invalid) slices of ranges
+func testValidateBruteForce[T int8 | uint8](t *testing.T, name string) {
+	endMark := rangeset.Universal[T]()[0].Top
+	r := rand.New(rand.NewPCG(57, 58))
+	for range 200 {
+		var in rangeset.Set[T]
+		var expected rangeset.Set[T]
+		for range r.IntN(6) {
+			v := rangeset.Span[T]{T(r.IntN(256)), T(r.IntN(256))}
+			if r.IntN(8) == 0 {
+				v.Top = endMark
+			}
+			in = append(in, v)
+			expected.AddRange(v.Bot, v.Top)
+		}
+		inCopy := in.Copy()
+		got := rangeset.NewFromSpans(in...)
+		Assertf(t, rangeset.Equal(got, expected), "%s NewFromSpans: %v: expected %v got %v", name, in, expected, got)
+		Assertf(t, rangeset.Equal(in, inCopy), "%s NewFromSpans: %v: modified its input", name, inCopy)
+		Assertf(t, got.Validate() == nil, "%s NewFromSpans: %v: expected valid set got %v", name, in, got.Validate())
+
+		// A set is valid if and only if normalizing it does not change it
+		valid := rangeset.Equal(in, expected) || len(in) == 0 && len(expected) == 0
+		Assertf(t, (in.Validate() == nil) == valid, "%s Validate: %v: expected valid %v got %v", name, in, valid, in.Validate())
+
+		// Sets created by the package should always be valid
+		s := randomSet[T](r)
+		Assertf(t, s.Validate() == nil, "%s Validate: %v: expected valid set got %v", name, s, s.Validate())
+	}
+}
+
+// TestValidateBruteForce tests Validate, Normalize and NewFromSpans using 8-bit elements
+func TestValidateBruteForce(t *testing.T) {
+	testValidateBruteForce[int8](t, "int8")
+	testValidateBruteForce[uint8](t, "uint8")
+}
@@ -0,0 +1,142 @@
+package rangeset
+
+// window.go implements ways to restrict a set to a "window" of elements [b, t) (using
+// asymmetric bounds): Clip modifies a set, whereas Window returns a read-only View of
+// part of a set without copying it.
+
+import (
+	"iter"
+	"math/big"
+)
+
+// window returns the indexes of the first range of the set that overlaps [b, t) and one past
+// the last range that overlaps it.  If no ranges overlap then first == end.
+func (s Set[T]) window(b, t T) (first, end int) {
+	var endMark = minInt[T]() // in a range it flags: bottom/top of all valid elements
+	if t <= b && t != endMark {
+		return 0, 0 // empty window
+	}
+	first = s.bsearch(b)
+	if first > 0 && b <= s[first-1].Top-1 {
+		first-- // b is within the range
+	}
+	end = len(s)
+	if t != endMark {
+		end = max(s.bsearch(t-1), first)
+	}
+	return
+}
+
+// Clip removes all elements of the set that are not in the range [b, t).
+// It has time complexity O(log r + k) where k is the number of ranges that are kept.
+func (s *Set[T]) Clip(b, t T) {
+	first, end := s.window(b, t)
+	*s = (*s)[:copy(*s, (*s)[first:end])]
+	if n := len(*s); n > 0 {
+		(*s)[0].Bot = max((*s)[0].Bot, b)
+		if (*s)[n-1].Top-1 > t-1 {
+			(*s)[n-1].Top = t
+		}
+	}
+}
+
+// View is a read-only view of the elements of a set within a range - see the Window method.
+// It shares the ranges of the set so becomes invalid if the set is modified.
+type View[T Element] struct {
+	spans       Set[T]  // the ranges of the set that overlap the window
+	first, last Span[T] // the first and last of the above ranges, trimmed to the window
+}
+
+// Window returns a View of the elements of the set in the range [b, t).  The View shares the
+// ranges of the set (rather than copying them) so it has time complexity of O(log r).
+func (s Set[T]) Window(b, t T) View[T] {
+	first, end := s.window(b, t)
+	v := View[T]{spans: s[first:end]}
+	if n := len(v.spans); n > 0 {
+		v.first, v.last = v.spans[0], v.spans[n-1]
+		v.first.Bot = max(v.first.Bot, b)
+		if v.last.Top-1 > t-1 {
+			v.last.Top = t
+		}
+		if n == 1 {
+			v.first.Top, v.last.Bot = v.last.Top, v.first.Bot // both ends of the same range are trimmed
+		}
+	}
+	return v
+}
+
+// span returns the range of the view at index idx
+func (v View[T]) span(idx int) Span[T] {
+	switch idx {
+	case 0:
+		return v.first
+	case len(v.spans) - 1:
+		return v.last
+	}
+	return v.spans[idx]
+}
+
+// Contains tests whether the view contains an element.  It has time complexity O(log r).
+func (v View[T]) Contains(e T) bool {
+	idx := v.spans.bsearch(e)
+	if idx == 0 {
+		return false
+	}
+	r := v.span(idx - 1)
+	return r.Bot <= e && e <= r.Top-1
+}
+
+// Length returns the number of elements and number of ranges in the view (see Set.Length)
+func (v View[T]) Length() (length uint64, spans int) {
+	for idx := range v.spans {
+		r := v.span(idx) // use the ranges directly (not SpansSeq) so that no closure is allocated
+		length += rangeLen(r.Bot, r.Top-1)
+	}
+	return length, len(v.spans)
+}
+
+// LengthBig returns the exact number of elements in the view (see Set.LengthBig)
+func (v View[T]) LengthBig() *big.Int {
+	var c count
+	for idx := range v.spans {
+		r := v.span(idx)
+		c.addLen(rangeLen(r.Bot, r.Top-1))
+	}
+	return c.bigInt()
+}
+
+// Seq returns a Go 1.23 iterator of the elements of the view in order
+func (v View[T]) Seq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for r := range v.SpansSeq() {
+			if !r.walk(yield) {
+				return
+			}
+		}
+	}
+}
+
+// SpansSeq returns a Go 1.23 iterator of the ranges of the view
+func (v View[T]) SpansSeq() iter.Seq[Span[T]] {
+	return func(yield func(Span[T]) bool) {
+		for idx := range v.spans {
+			if !yield(v.span(idx)) {
+				return
+			}
+		}
+	}
+}
+
+// String serialises the view in the same format as Set.String
+func (v View[T]) String() string {
+	return spansString(len(v.spans), v.SpansSeq())
+}
+
+// Copy returns a new set containing the elements of the view
+func (v View[T]) Copy() Set[T] {
+	retval := make(Set[T], 0, len(v.spans))
+	for r := range v.SpansSeq() {
+		retval = append(retval, r)
+	}
+	return retval
+}
@@ -0,0 +1,98 @@
+package rangeset_test
+
+import (
+	"github.com/andrewwphillips/rangeset"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+type WindowElementType int
+
+// windowData provides table data for testing Clip and Window
+var windowData = map[string]struct {
+	in       string
+	b, t     WindowElementType
+	expected string
+}{
+	"Empty":       {"{}", 1, 10, "{}"},
+	"EmptyWindow": {"{1:20}", 10, 10, "{}"},
+	"Reversed":    {"{1:20}", 10, 5, "{}"},
+	"Inside":      {"{1:20}", 5, 10, "{5:9}"},
+	"Whole":       {"{1:5,7:10}", 0, 20, "{1:5,7:10}"},
+	"TrimBoth":    {"{1:5,7:10,12:15}", 3, 14, "{3:5,7:10,12:13}"},
+	"Exact":       {"{1:5,7:10,12:15}", 7, 11, "{7:10}"},
+	"InGap":       {"{1:5,7:10,12:15}", 6, 7, "{}"},
+	"Before":      {"{1:5,7:10}", -10, 0, "{}"},
+	"After":       {"{1:5,7:10}", 11, 100, "{}"},
+	"ToEnd":       {"{1:5,7:E}", 3, -1 << 63, "{3:5,7:E}"},
+	"Universal":   {"{U}", -5, 5, "{-5:4}"},
+}
+
+// TestWindowTable tests Clip and Window using the windowData table
+func TestWindowTable(t *testing.T) {
+	for name, data := range windowData {
+		s, _ := rangeset.NewFromString[WindowElementType](data.in)
+		orig := s.Copy()
+		expected, _ := rangeset.NewFromString[WindowElementType](data.expected)
+
+		v := s.Window(data.b, data.t)
+		Assertf(t, v.String() == expected.String(), "Window: %12s: expected %v got %v", name, expected, v)
+		Assertf(t, rangeset.Equal(s, orig), "Window: %12s: set was modified from %v to %v", name, orig, s)
+
+		s.Clip(data.b, data.t)
+		Assertf(t, rangeset.Equal(s, expected), "Clip: %12s: expected %v got %v", name, expected, s)
+	}
+}
+
+// testWindowBruteForce checks Clip and Window against brute force calculations
+func testWindowBruteForce[T int8 | uint8](t *testing.T, name string) {
+	endMark := rangeset.Universal[T]()[0].Top
+	r := rand.New(rand.NewPCG(23, 24))
+	for range 500 {
+		s := randomSet[T](r)
+		b, top := T(r.IntN(256)), T(r.IntN(256))
+		if r.IntN(4) == 0 {
+			top = endMark
+		}
+		expected := bruteForce(func(e T) bool {
+			return s.Contains(e) && e >= b && (e < top || top == endMark) && (top > b || top == endMark)
+		})
+
+		v := s.Window(b, top)
+		Assertf(t, rangeset.Equal(v.Copy(), expected), "%s Window: %v [%d,%d) expected %v got %v", name, s, b, top, expected, v)
+		Assertf(t, v.String() == expected.String(), "%s Window: %v [%d,%d) expected %v got %v", name, s, b, top, expected, v)
+		length, spans := v.Length()
+		expLength, expSpans := expected.Length()
+		Assertf(t, length == expLength && spans == expSpans, "%s Window: %v [%d,%d) expected length %d,%d got %d,%d",
+			name, s, b, top, expLength, expSpans, length, spans)
+		Assertf(t, slices.Equal(slices.Collect(v.Seq()), elements(expected)), "%s Window: %v [%d,%d) Seq expected %v got %v",
+			name, s, b, top, elements(expected), slices.Collect(v.Seq()))
+		contains := bruteForce(v.Contains)
+		Assertf(t, rangeset.Equal(contains, expected), "%s Window: %v [%d,%d) Contains expected %v got %v",
+			name, s, b, top, expected, contains)
+
+		s.Clip(b, top)
+		Assertf(t, rangeset.Equal(s, expected), "%s Clip: [%d,%d) expected %v got %v", name, b, top, expected, s)
+	}
+}
+
+// TestWindowBruteForce tests Clip and Window using 8-bit elements
+func TestWindowBruteForce(t *testing.T) {
+	testWindowBruteForce[int8](t, "int8")
+	testWindowBruteForce[uint8](t, "uint8")
+}
+
+// TestWindowShares checks that a View shares the ranges of the set
+func TestWindowShares(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocations are not counted reliably with the race detector")
+	}
+	s, _ := rangeset.NewFromString[WindowElementType]("{1:5,7:10,12:15,20:30}")
+	allocs := testing.AllocsPerRun(100, func() {
+		v := s.Window(3, 25)
+		_ = v.Contains(8)
+		_, _ = v.Length()
+	})
+	Assertf(t, allocs == 0, "WindowShares: expected no allocations got %v", allocs)
+}